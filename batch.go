@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"normalizer/scraper"
+)
+
+const (
+	// maxBatchJobs caps how many jobs a single batch request may contain
+	maxBatchJobs = 20
+	// batchConcurrency is how many batch jobs run at the same time
+	batchConcurrency = 3
+)
+
+// batchRequest is the body of POST /scrape/batch
+type batchRequest struct {
+	Jobs []batchJob `json:"jobs"`
+}
+
+// batchJob describes one scrape within a batch
+type batchJob struct {
+	Source    string `json:"source"`
+	Team      string `json:"team,omitempty"`
+	Normalize bool   `json:"normalize,omitempty"`
+}
+
+// batchJobResult is the outcome of one job; exactly one of Result and Error is set
+type batchJobResult struct {
+	Job    batchJob                `json:"job"`
+	Result *scraper.ScrapingResult `json:"result,omitempty"`
+	Error  string                  `json:"error,omitempty"`
+}
+
+// handleBatch runs several scrape jobs concurrently and returns their results in order
+func (ws *WebServer) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Jobs) == 0 {
+		http.Error(w, "No jobs given", http.StatusBadRequest)
+		return
+	}
+	if len(req.Jobs) > maxBatchJobs {
+		http.Error(w, fmt.Sprintf("Too many jobs: %d (max %d)", len(req.Jobs), maxBatchJobs), http.StatusBadRequest)
+		return
+	}
+
+	results := make([]batchJobResult, len(req.Jobs))
+	sem := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+
+	for i, job := range req.Jobs {
+		wg.Add(1)
+		go func(i int, job batchJob) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = ws.runBatchJob(r.Context(), job)
+		}(i, job)
+	}
+	wg.Wait()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// runBatchJob scrapes a single job, reporting failures in the result instead of failing the batch
+func (ws *WebServer) runBatchJob(ctx context.Context, job batchJob) batchJobResult {
+	jobResult := batchJobResult{Job: job}
+
+	if job.Source == "" {
+		job.Source = ws.config.DefaultSource
+		jobResult.Job.Source = job.Source
+	}
+
+	var teamName string
+	if job.Team != "" {
+		var ok bool
+		teamName, ok = ws.normalizer.ResolveTeamSlug(job.Team)
+		if !ok {
+			jobResult.Error = fmt.Sprintf("unknown team: %s", job.Team)
+			return jobResult
+		}
+	}
+
+	result, err := ws.scrapeCached(ctx, job.Source)
+	if errors.Is(err, errInvalidSource) {
+		jobResult.Error = fmt.Sprintf("invalid source: %s", job.Source)
+		return jobResult
+	}
+	if err != nil {
+		jobResult.Error = fmt.Sprintf("scraping failed: %v", err)
+		return jobResult
+	}
+
+	if job.Normalize {
+		result = ws.normalizeCached(ctx, job.Source, result, 0)
+	}
+	if teamName != "" {
+		result = ws.normalizer.FilterByTeam(result, teamName)
+	}
+
+	jobResult.Result = result
+	return jobResult
+}
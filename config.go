@@ -0,0 +1,585 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"normalizer/scraper"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration wraps time.Duration so it can be written as "30s" in config files
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalJSON parses a duration string such as "1m30s"
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"30s\": %w", err)
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+
+	d.Duration = parsed
+	return nil
+}
+
+// MarshalJSON writes the duration in its string form
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Config holds the server settings that can be loaded from a file
+type Config struct {
+	Port             string              `json:"port"`
+	DefaultSource    string              `json:"default_source"`
+	CacheTTL         Duration            `json:"cache_ttl"`          // 0 disables caching
+	RateLimit        Duration            `json:"rate_limit"`         // Delay between requests to the same site
+	GlobalRPM        int                 `json:"global_rpm"`         // Outbound requests per minute across all sources; 0 disables
+	GlobalBurst      int                 `json:"global_burst"`       // Requests global_rpm lets through back to back before spacing them out
+	RequestTimeout   Duration            `json:"request_timeout"`    // Timeout for a single scrape
+	SourceTimeouts   map[string]Duration `json:"source_timeouts"`    // Per-source scrape timeouts, overriding request_timeout
+	AllBudget        Duration            `json:"all_budget"`         // Total time allowed for an "all" scrape
+	AllMinInterval   Duration            `json:"all_min_interval"`   // Least time between two real "all" scrapes; 0 disables
+	MaxPages         int                 `json:"max_pages"`          // VividSeats listing pages to follow
+	MaxBrowsers      int                 `json:"max_browsers"`       // Chrome instances Sport365 scrapes may run at once
+	RequiredSources  []string            `json:"required_sources"`   // Sources whose failure fails an "all" scrape
+	FallbackSources  []string            `json:"fallback_sources"`   // Sources a "fallback" scrape tries, in order
+	MinHealthyEvents int                 `json:"min_healthy_events"` // Fewest events a source may return in a deep health check
+	MappingsFile     string              `json:"mappings_file"`
+	StrictMappings   bool                `json:"strict_mappings"`
+	NationalTeams    bool                `json:"national_teams"`  // Also normalize country names for international fixtures
+	SimilarityGap    float64             `json:"similarity_gap"`  // Fuzzy matches closer than this to a second team are skipped
+	Timezone         string              `json:"timezone"`        // Used to interpret from/to dates
+	SourceTimezone   string              `json:"source_timezone"` // Zone scraped event times are local to
+	Language         string              `json:"language"`        // Accept-Language sent to the sites
+	UserAgent        string              `json:"user_agent"`      // User-Agent sent to the sites; empty uses the built-in one
+	CaptureRaw       bool                `json:"capture_raw"`     // Include each listing's raw HTML in results
+	RetryEmpty       bool                `json:"retry_empty"`     // Retry a HelloTickets/VividSeats scrape once when it finds no events
+	Warmup           bool                `json:"warmup"`          // Visit the HelloTickets/VividSeats homepage first to pick up cookies
+	LogFormat        string              `json:"log_format"`      // "text" or "json" lines for the access and scraper logs
+	RedactParams     []string            `json:"redact_params"`   // Query params whose values are masked in the access log
+	OfflineDir       string              `json:"offline_dir"`     // Directory of <source>.html fixtures to scrape instead of the live sites
+	EnableMock       bool                `json:"enable_mock"`     // Serve fixed data for source=mock; for development only
+	SeenFile         string              `json:"seen_file"`       // Where seen listings are persisted for /scrape/new; empty keeps them in memory
+	WatchlistFile    string              `json:"watchlist_file"`  // "source team" pairs refreshed in the background
+	WatchInterval    Duration            `json:"watch_interval"`  // How often the watchlist is refreshed
+	MatchPattern     string              `json:"match_pattern"`   // Regular expression an event name must match to count as a fixture with matchesOnly
+	SkipWarnRatio    float64             `json:"skip_warn_ratio"` // Share of skipped listing elements above which a scrape logs a layout warning; 0 never warns
+
+	DedupFuzzyThreshold float64 `json:"dedup_fuzzy_threshold"` // Team name similarity at which dedupFuzzy treats two listings as the same match
+	DedupDayTolerance   int     `json:"dedup_day_tolerance"`   // Days dedupFuzzy allows between the dates of the same match
+
+	Sources              map[string]SourceConfig `json:"sources"`               // Per-source overrides keyed by source name
+	ListingTypeKeywords  map[string][]string     `json:"listing_type_keywords"` // Title keywords marking non-match listings, keyed by type
+	VividSeatsPerformers []string                `json:"vividseats_performers"` // VividSeats performer IDs listing Real Madrid, e.g. one per competition
+}
+
+// SourceConfig holds per-source overrides, such as selectors for patching a
+// site whose markup changed without rebuilding
+type SourceConfig struct {
+	scraper.Selectors
+	MaxRedirects        *int     `json:"max_redirects"`        // Redirects followed per request; unset keeps the default of 10 and 0 stops at the first redirect
+	UnexpectedRedirects []string `json:"unexpected_redirects"` // Path prefixes a redirect must not lead to, e.g. "/region-select"
+	DisplayName         string   `json:"display_name"`         // Shown instead of the built-in display name with displayNames=true
+}
+
+// UnmarshalJSON rejects unknown keys and empty selectors so typos don't
+// silently fall back to the defaults
+func (sc *SourceConfig) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	known := map[string]bool{
+		"listing_selector": true, "link_selector": true, "event_selector": true,
+		"date_selector": true, "day_selector": true, "time_selector": true,
+		"home_team_selector": true, "away_team_selector": true, "price_selector": true,
+		"display_name": true,
+	}
+	for key, value := range raw {
+		switch {
+		case key == "max_redirects":
+			if n, ok := value.(float64); !ok || n < 0 || n != float64(int(n)) {
+				return fmt.Errorf("max_redirects must be a non-negative integer")
+			}
+		case key == "unexpected_redirects":
+			prefixes, ok := value.([]interface{})
+			if !ok {
+				return fmt.Errorf("unexpected_redirects must be a list of paths")
+			}
+			for _, prefix := range prefixes {
+				if str, ok := prefix.(string); !ok || !strings.HasPrefix(str, "/") {
+					return fmt.Errorf("unexpected_redirects entries must be paths starting with /")
+				}
+			}
+		case !known[key]:
+			return fmt.Errorf("unknown source setting %q", key)
+		default:
+			if str, ok := value.(string); !ok || strings.TrimSpace(str) == "" {
+				return fmt.Errorf("%s must be a non-empty string", key)
+			}
+		}
+	}
+
+	// The alias has the same fields without this method, so decoding it doesn't recurse
+	type plain SourceConfig
+	return json.Unmarshal(data, (*plain)(sc))
+}
+
+// DefaultConfig returns the settings used when no config file or flags are given
+func DefaultConfig() Config {
+	defaults := scraper.DefaultScraperOptions()
+	return Config{
+		Port:           "8080",
+		DefaultSource:  "hellotickets",
+		RateLimit:      Duration{defaults.RequestDelay},
+		GlobalBurst:    1,
+		RequestTimeout: Duration{defaults.Timeout},
+		SourceTimeouts: map[string]Duration{
+			"hellotickets": {15 * time.Second},
+			"vividseats":   {15 * time.Second},
+			"sport365":     {40 * time.Second}, // Chrome has to start and render the page
+		},
+		AllBudget:        Duration{45 * time.Second}, // Room for the slowest source timeout
+		MaxPages:         defaults.MaxPages,
+		MaxBrowsers:      1,
+		FallbackSources:  []string{"vividseats", "hellotickets"},
+		MinHealthyEvents: 1,
+		Language:         defaults.Language,
+		SimilarityGap:    0.05,
+		Timezone:         "UTC",
+		SourceTimezone:   "Europe/Madrid",
+		WatchInterval:    Duration{15 * time.Minute},
+		LogFormat:        "text",
+		RedactParams:     []string{"apikey", "token"},
+		MatchPattern:     scraper.DefaultMatchPattern,
+		SkipWarnRatio:    scraper.DefaultSkipWarnRatio,
+
+		DedupFuzzyThreshold:  0.9,
+		VividSeatsPerformers: scraper.DefaultVividSeatsPerformers,
+	}
+}
+
+// LoadConfig reads a JSON config file, or a YAML one ending in .yaml or .yml, on top of the defaults
+func LoadConfig(path string) (Config, error) {
+	config := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// YAML is converted to JSON, so both formats share the same decoding and validation
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		if data, err = yamlToJSON(data); err != nil {
+			return config, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// yamlToJSON re-encodes a YAML document as JSON
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// Validate checks that the config values are usable
+func (c Config) Validate() error {
+	if c.Port == "" {
+		return fmt.Errorf("port must not be empty")
+	}
+	if c.SimilarityGap < 0 || c.SimilarityGap >= 1 {
+		return fmt.Errorf("similarity_gap must be between 0 and 1")
+	}
+	if c.MinHealthyEvents < 0 {
+		return fmt.Errorf("min_healthy_events must not be negative")
+	}
+	if c.MaxPages < 1 {
+		return fmt.Errorf("max_pages must be at least 1")
+	}
+	if len(c.VividSeatsPerformers) == 0 {
+		return fmt.Errorf("vividseats_performers must not be empty")
+	}
+	for _, id := range c.VividSeatsPerformers {
+		if _, err := strconv.Atoi(id); err != nil {
+			return fmt.Errorf("invalid VividSeats performer ID %q", id)
+		}
+	}
+	if c.LogFormat != "text" && c.LogFormat != "json" {
+		return fmt.Errorf("log_format must be text or json")
+	}
+	if c.MaxBrowsers < 1 {
+		return fmt.Errorf("max_browsers must be at least 1")
+	}
+	if c.GlobalRPM < 0 {
+		return fmt.Errorf("global_rpm must not be negative")
+	}
+	if c.GlobalBurst < 1 {
+		return fmt.Errorf("global_burst must be at least 1")
+	}
+	if c.DedupFuzzyThreshold <= 0 || c.DedupFuzzyThreshold > 1 {
+		return fmt.Errorf("dedup_fuzzy_threshold must be above 0 and at most 1")
+	}
+	if c.SkipWarnRatio < 0 || c.SkipWarnRatio >= 1 {
+		return fmt.Errorf("skip_warn_ratio must be at least 0 and below 1")
+	}
+	if c.DedupDayTolerance < 0 {
+		return fmt.Errorf("dedup_day_tolerance must not be negative")
+	}
+	if _, err := regexp.Compile(c.MatchPattern); err != nil {
+		return fmt.Errorf("invalid match_pattern: %w", err)
+	}
+	if c.WatchlistFile != "" && c.WatchInterval.Duration <= 0 {
+		return fmt.Errorf("watch_interval must be positive when a watchlist is set")
+	}
+	if c.CacheTTL.Duration < 0 || c.RateLimit.Duration < 0 || c.RequestTimeout.Duration < 0 || c.AllBudget.Duration < 0 || c.AllMinInterval.Duration < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	for source, sourceConfig := range c.Sources {
+		if !scraper.IsSource(source) {
+			return fmt.Errorf("unknown source %q in sources", source)
+		}
+		if err := sourceConfig.Selectors.Validate(); err != nil {
+			return fmt.Errorf("sources.%s: %w", source, err)
+		}
+	}
+	for source, timeout := range c.SourceTimeouts {
+		if !scraper.IsSource(source) {
+			return fmt.Errorf("unknown source %q in source_timeouts", source)
+		}
+		if timeout.Duration <= 0 {
+			return fmt.Errorf("source_timeouts.%s must be positive", source)
+		}
+		if c.AllBudget.Duration > 0 && timeout.Duration > c.AllBudget.Duration {
+			log.Printf("Warning: source_timeouts.%s (%v) exceeds all_budget (%v), so \"all\" scrapes cut %s off first", source, timeout, c.AllBudget, source)
+		}
+	}
+	for listingType := range c.ListingTypeKeywords {
+		if listingType == "" || listingType == scraper.ListingTypeMatch {
+			return fmt.Errorf("invalid listing type %q in listing_type_keywords", listingType)
+		}
+	}
+	if len(c.FallbackSources) == 0 {
+		return fmt.Errorf("fallback_sources must not be empty")
+	}
+	for _, source := range c.FallbackSources {
+		if !scraper.IsSource(source) {
+			return fmt.Errorf("unknown fallback source %q", source)
+		}
+	}
+	for _, source := range c.RequiredSources {
+		if !scraper.IsSource(source) {
+			return fmt.Errorf("unknown required source %q", source)
+		}
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	if _, err := time.LoadLocation(c.SourceTimezone); err != nil {
+		return fmt.Errorf("invalid source_timezone %q: %w", c.SourceTimezone, err)
+	}
+	return nil
+}
+
+// ScraperOptions returns the scraper options derived from the config
+func (c Config) ScraperOptions() scraper.ScraperOptions {
+	opts := scraper.DefaultScraperOptions()
+	opts.RequestDelay = c.RateLimit.Duration
+	opts.MaxPages = c.MaxPages
+	opts.VividSeatsPerformers = c.VividSeatsPerformers
+	opts.Language = c.Language
+	opts.CaptureRaw = c.CaptureRaw
+	opts.RetryEmpty = c.RetryEmpty
+	opts.Warmup = c.Warmup
+	opts.SkipWarnRatio = c.SkipWarnRatio
+	if c.UserAgent != "" {
+		opts.UserAgent = c.UserAgent
+	}
+	if c.ListingTypeKeywords != nil {
+		opts.ListingTypeKeywords = c.ListingTypeKeywords
+	}
+	if c.RequestTimeout.Duration > 0 {
+		opts.Timeout = c.RequestTimeout.Duration
+	}
+	return opts
+}
+
+// ScraperOptionsFor returns the scraper options for one source, including its selector overrides and timeout
+func (c Config) ScraperOptionsFor(source string) scraper.ScraperOptions {
+	opts := c.ScraperOptions()
+	if sourceConfig, ok := c.Sources[source]; ok {
+		opts.Selectors = sourceConfig.Selectors
+		opts.MaxRedirects = sourceConfig.MaxRedirects
+		opts.UnexpectedRedirects = sourceConfig.UnexpectedRedirects
+	}
+	opts.Timeout = c.TimeoutFor(source)
+	return opts
+}
+
+// DisplayNames returns the display names configured for sources, keyed by source
+func (c Config) DisplayNames() map[string]string {
+	names := make(map[string]string)
+	for source, sourceConfig := range c.Sources {
+		if sourceConfig.DisplayName != "" {
+			names[source] = sourceConfig.DisplayName
+		}
+	}
+	return names
+}
+
+// TimeoutFor returns how long a scrape of the source may take: its entry in
+// source_timeouts, or request_timeout for sources without one
+func (c Config) TimeoutFor(source string) time.Duration {
+	if timeout, ok := c.SourceTimeouts[source]; ok {
+		return timeout.Duration
+	}
+	return c.ScraperOptions().Timeout
+}
+
+// configFlags holds the command-line flags that can override config file values
+type configFlags struct {
+	configFile      *string
+	port            *string
+	defaultSource   *string
+	cacheTTL        *time.Duration
+	rateLimit       *time.Duration
+	globalRPM       *int
+	globalBurst     *int
+	requestTimeout  *time.Duration
+	sourceTimeouts  *string
+	allBudget       *time.Duration
+	allMinInterval  *time.Duration
+	maxPages        *int
+	performers      *string
+	maxBrowsers     *int
+	requiredSources *string
+	fallbackSources *string
+	minHealthy      *int
+	mappingsFile    *string
+	strictMappings  *bool
+	nationalTeams   *bool
+	similarityGap   *float64
+	timezone        *string
+	sourceTimezone  *string
+	language        *string
+	userAgent       *string
+	captureRaw      *bool
+	retryEmpty      *bool
+	warmup          *bool
+	logFormat       *string
+	redactParams    *string
+	offlineDir      *string
+	enableMock      *bool
+	seenFile        *string
+	watchlistFile   *string
+	watchInterval   *time.Duration
+	matchPattern    *string
+	fuzzyThreshold  *float64
+	dayTolerance    *int
+	skipWarnRatio   *float64
+}
+
+// registerConfigFlags defines the config flags on the given flag set
+func registerConfigFlags(fs *flag.FlagSet) *configFlags {
+	defaults := DefaultConfig()
+	return &configFlags{
+		configFile:      fs.String("config", "", "Optional JSON or YAML config file (.json, .yaml, .yml)"),
+		port:            fs.String("port", defaults.Port, "Port to run the web server on"),
+		defaultSource:   fs.String("default-source", defaults.DefaultSource, "Source used when the request does not specify one"),
+		cacheTTL:        fs.Duration("cache-ttl", defaults.CacheTTL.Duration, "How long scrape results are cached (0 disables)"),
+		rateLimit:       fs.Duration("rate-limit", defaults.RateLimit.Duration, "Delay between requests to the same site"),
+		globalRPM:       fs.Int("global-rpm", defaults.GlobalRPM, "Outbound requests per minute across all sources (0 disables)"),
+		globalBurst:     fs.Int("global-burst", defaults.GlobalBurst, "Requests -global-rpm lets through back to back before spacing them out"),
+		requestTimeout:  fs.Duration("timeout", defaults.RequestTimeout.Duration, "Timeout for a single scrape"),
+		sourceTimeouts:  fs.String("source-timeouts", "", "Comma-separated per-source timeouts, e.g. sport365=60s,vividseats=10s"),
+		allBudget:       fs.Duration("all-budget", defaults.AllBudget.Duration, "Total time allowed for an \"all\" scrape (0 disables)"),
+		allMinInterval:  fs.Duration("all-min-interval", defaults.AllMinInterval.Duration, "Least time between two real \"all\" scrapes; within it the last result is served again (0 disables)"),
+		maxPages:        fs.Int("max-pages", defaults.MaxPages, "VividSeats listing pages to follow"),
+		performers:      fs.String("vividseats-performers", strings.Join(defaults.VividSeatsPerformers, ","), "Comma-separated VividSeats performer IDs to scrape and merge"),
+		maxBrowsers:     fs.Int("max-browsers", defaults.MaxBrowsers, "Chrome instances Sport365 scrapes may run at once; others queue"),
+		requiredSources: fs.String("required-sources", "", "Comma-separated sources whose failure fails an \"all\" scrape"),
+		fallbackSources: fs.String("fallback-sources", strings.Join(defaults.FallbackSources, ","), "Comma-separated sources a \"fallback\" scrape tries, in order"),
+		minHealthy:      fs.Int("min-healthy-events", defaults.MinHealthyEvents, "Fewest events a source may return in a deep health check"),
+		mappingsFile:    fs.String("mappings", defaults.MappingsFile, "Optional JSON file with extra team name mappings"),
+		strictMappings:  fs.Bool("strict-mappings", defaults.StrictMappings, "Fail on startup if the mappings file conflicts with the defaults"),
+		nationalTeams:   fs.Bool("national-teams", defaults.NationalTeams, "Normalize national team names given in several languages"),
+		similarityGap:   fs.Float64("similarity-gap", defaults.SimilarityGap, "Skip fuzzy team matches whose score is this close to a second team"),
+		timezone:        fs.String("tz", defaults.Timezone, "Timezone used to interpret from/to dates"),
+		language:        fs.String("language", defaults.Language, "Accept-Language sent to the sites, so team names come back in one language"),
+		userAgent:       fs.String("user-agent", defaults.UserAgent, "User-Agent sent to the sites (default: a desktop Chrome UA)"),
+		captureRaw:      fs.Bool("capture-raw", defaults.CaptureRaw, "Include each listing's raw HTML in results for auditing"),
+		retryEmpty:      fs.Bool("retry-empty", defaults.RetryEmpty, "Retry a HelloTickets/VividSeats scrape once when it finds no events"),
+		warmup:          fs.Bool("warmup", defaults.Warmup, "Visit the HelloTickets/VividSeats homepage before the listing to pick up anti-bot cookies"),
+		logFormat:       fs.String("log-format", defaults.LogFormat, "Log format: text or json (one JSON object per line)"),
+		redactParams:    fs.String("redact-params", strings.Join(defaults.RedactParams, ","), "Comma-separated query params whose values are masked in the access log"),
+		sourceTimezone:  fs.String("source-tz", defaults.SourceTimezone, "Timezone scraped event times are local to"),
+		watchlistFile:   fs.String("watchlist", defaults.WatchlistFile, "File of \"source team\" pairs to refresh in the background (reloaded on SIGHUP)"),
+		watchInterval:   fs.Duration("watch-interval", defaults.WatchInterval.Duration, "How often the watchlist is refreshed"),
+		seenFile:        fs.String("seen-file", defaults.SeenFile, "JSON file that persists seen listings for /scrape/new across restarts"),
+		enableMock:      fs.Bool("enable-mock", defaults.EnableMock, "Serve fixed sample data for source=mock (development only)"),
+		offlineDir:      fs.String("offline-dir", defaults.OfflineDir, "Scrape <source>.html fixtures from this directory instead of the live sites"),
+		fuzzyThreshold:  fs.Float64("dedup-fuzzy-threshold", defaults.DedupFuzzyThreshold, "Team name similarity at which dedupFuzzy treats two listings as the same match"),
+		dayTolerance:    fs.Int("dedup-day-tolerance", defaults.DedupDayTolerance, "Days dedupFuzzy allows between the dates of the same match"),
+		skipWarnRatio:   fs.Float64("skip-warn-ratio", defaults.SkipWarnRatio, "Share of skipped listing elements above which a scrape logs a layout warning (0 never warns)"),
+		matchPattern:    fs.String("match-pattern", defaults.MatchPattern, "Regular expression an event name must match to count as a fixture with matchesOnly"),
+	}
+}
+
+// resolve builds the final config: defaults, then the config file, then explicitly set flags
+func (f *configFlags) resolve(fs *flag.FlagSet) (Config, error) {
+	config := DefaultConfig()
+	if *f.configFile != "" {
+		var err error
+		config, err = LoadConfig(*f.configFile)
+		if err != nil {
+			return config, err
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "port":
+			config.Port = *f.port
+		case "default-source":
+			config.DefaultSource = *f.defaultSource
+		case "cache-ttl":
+			config.CacheTTL = Duration{*f.cacheTTL}
+		case "rate-limit":
+			config.RateLimit = Duration{*f.rateLimit}
+		case "global-rpm":
+			config.GlobalRPM = *f.globalRPM
+		case "global-burst":
+			config.GlobalBurst = *f.globalBurst
+		case "timeout":
+			config.RequestTimeout = Duration{*f.requestTimeout}
+		case "source-timeouts":
+			flagErr = config.mergeSourceTimeouts(*f.sourceTimeouts)
+		case "all-budget":
+			config.AllBudget = Duration{*f.allBudget}
+		case "all-min-interval":
+			config.AllMinInterval = Duration{*f.allMinInterval}
+		case "max-pages":
+			config.MaxPages = *f.maxPages
+		case "vividseats-performers":
+			config.VividSeatsPerformers = splitList(*f.performers)
+		case "max-browsers":
+			config.MaxBrowsers = *f.maxBrowsers
+		case "required-sources":
+			config.RequiredSources = splitList(*f.requiredSources)
+		case "fallback-sources":
+			config.FallbackSources = splitList(*f.fallbackSources)
+		case "min-healthy-events":
+			config.MinHealthyEvents = *f.minHealthy
+		case "mappings":
+			config.MappingsFile = *f.mappingsFile
+		case "strict-mappings":
+			config.StrictMappings = *f.strictMappings
+		case "national-teams":
+			config.NationalTeams = *f.nationalTeams
+		case "similarity-gap":
+			config.SimilarityGap = *f.similarityGap
+		case "tz":
+			config.Timezone = *f.timezone
+		case "language":
+			config.Language = *f.language
+		case "user-agent":
+			config.UserAgent = *f.userAgent
+		case "capture-raw":
+			config.CaptureRaw = *f.captureRaw
+		case "retry-empty":
+			config.RetryEmpty = *f.retryEmpty
+		case "warmup":
+			config.Warmup = *f.warmup
+		case "log-format":
+			config.LogFormat = *f.logFormat
+		case "redact-params":
+			config.RedactParams = splitList(*f.redactParams)
+		case "source-tz":
+			config.SourceTimezone = *f.sourceTimezone
+		case "watchlist":
+			config.WatchlistFile = *f.watchlistFile
+		case "watch-interval":
+			config.WatchInterval = Duration{*f.watchInterval}
+		case "seen-file":
+			config.SeenFile = *f.seenFile
+		case "enable-mock":
+			config.EnableMock = *f.enableMock
+		case "offline-dir":
+			config.OfflineDir = *f.offlineDir
+		case "match-pattern":
+			config.MatchPattern = *f.matchPattern
+		case "dedup-fuzzy-threshold":
+			config.DedupFuzzyThreshold = *f.fuzzyThreshold
+		case "dedup-day-tolerance":
+			config.DedupDayTolerance = *f.dayTolerance
+		case "skip-warn-ratio":
+			config.SkipWarnRatio = *f.skipWarnRatio
+		}
+	})
+
+	if flagErr != nil {
+		return config, flagErr
+	}
+	return config, config.Validate()
+}
+
+// mergeSourceTimeouts sets the timeouts given as "source=duration" pairs,
+// keeping the other sources' timeouts
+func (c *Config) mergeSourceTimeouts(value string) error {
+	timeouts := make(map[string]Duration)
+	for source, timeout := range c.SourceTimeouts {
+		timeouts[source] = timeout
+	}
+
+	for _, pair := range splitList(value) {
+		source, durationStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid -source-timeouts entry %q: expected source=duration", pair)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(durationStr))
+		if err != nil {
+			return fmt.Errorf("invalid -source-timeouts entry %q: %w", pair, err)
+		}
+		timeouts[strings.TrimSpace(source)] = Duration{timeout}
+	}
+
+	c.SourceTimeouts = timeouts
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"normalizer/scraper"
+)
+
+// writeConfig writes a config file named name into a temporary directory
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// resolveArgs resolves the config from command-line args
+func resolveArgs(t *testing.T, args ...string) (Config, error) {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := registerConfigFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return flags.resolve(fs)
+}
+
+func TestResolveDefaultsWithoutFileOrFlags(t *testing.T) {
+	config, err := resolveArgs(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := DefaultConfig()
+	if config.Port != defaults.Port || config.DefaultSource != defaults.DefaultSource || config.CacheTTL != defaults.CacheTTL {
+		t.Errorf("got port %q, source %q, ttl %v; want the defaults", config.Port, config.DefaultSource, config.CacheTTL)
+	}
+}
+
+func TestLoadConfigJSON(t *testing.T) {
+	path := writeConfig(t, "config.json", `{"port": "9090", "cache_ttl": "5m", "timezone": "Europe/Madrid"}`)
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Port != "9090" || config.CacheTTL.Duration != 5*time.Minute || config.Timezone != "Europe/Madrid" {
+		t.Errorf("got port %q, ttl %v, tz %q", config.Port, config.CacheTTL, config.Timezone)
+	}
+	// Keys the file leaves out keep their defaults
+	if config.DefaultSource != DefaultConfig().DefaultSource {
+		t.Errorf("default source = %q, want the default", config.DefaultSource)
+	}
+}
+
+func TestLoadConfigYAML(t *testing.T) {
+	for _, name := range []string{"config.yaml", "config.yml"} {
+		t.Run(name, func(t *testing.T) {
+			path := writeConfig(t, name, `
+port: "9091"
+cache_ttl: 2m
+source_timeouts:
+  sport365: 60s
+sources:
+  vividseats:
+    max_redirects: 3
+`)
+			config, err := LoadConfig(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Port != "9091" || config.CacheTTL.Duration != 2*time.Minute {
+				t.Errorf("got port %q, ttl %v", config.Port, config.CacheTTL)
+			}
+			if config.SourceTimeouts["sport365"].Duration != time.Minute {
+				t.Errorf("sport365 timeout = %v, want 1m", config.SourceTimeouts["sport365"])
+			}
+			if n := config.Sources["vividseats"].MaxRedirects; n == nil || *n != 3 {
+				t.Errorf("vividseats max_redirects = %v, want 3", n)
+			}
+		})
+	}
+}
+
+func TestMaxRedirectsZeroIsKept(t *testing.T) {
+	path := writeConfig(t, "config.json", `{"sources": {"vividseats": {"max_redirects": 0}, "hellotickets": {"display_name": "Hello"}}}`)
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 0 stops at the first redirect rather than meaning the default
+	if n := config.ScraperOptionsFor("vividseats").MaxRedirects; n == nil || *n != 0 {
+		t.Errorf("vividseats max_redirects = %v, want 0", n)
+	}
+	if n := config.ScraperOptionsFor("hellotickets").MaxRedirects; n != nil {
+		t.Errorf("hellotickets max_redirects = %v, want unset", *n)
+	}
+}
+
+func TestLoadConfigRejectsUnknownSourceSetting(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "sources:\n  vividseats:\n    listing_selectr: div\n")
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("expected an error for a misspelt source setting")
+	}
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("expected an error for a missing config file")
+	}
+}
+
+func TestFlagsOverrideConfigFile(t *testing.T) {
+	path := writeConfig(t, "config.json", `{"port": "9090", "cache_ttl": "5m"}`)
+	config, err := resolveArgs(t, "-config", path, "-port", "7070")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Port != "7070" {
+		t.Errorf("port = %q, want the flag's 7070", config.Port)
+	}
+	if config.CacheTTL.Duration != 5*time.Minute {
+		t.Errorf("cache ttl = %v, want the file's 5m", config.CacheTTL)
+	}
+}
+
+func TestSimilarityGapZeroTurnsCheckOff(t *testing.T) {
+	path := writeConfig(t, "config.json", `{"similarity_gap": 0}`)
+	config, err := resolveArgs(t, "-config", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.SimilarityGap != 0 {
+		t.Fatalf("similarity_gap = %v, want 0", config.SimilarityGap)
+	}
+
+	ws, err := NewWebServer(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := ws.normalizer.NormalizeEvent(&scraper.TicketEvent{Event: "Real Madrid vs Manchester"})
+	if event.AwayTeam != "Manchester United" {
+		t.Errorf("ambiguous away team normalized to %q, want the best match with the check off", event.AwayTeam)
+	}
+}
+
+func TestDefaultAllBudgetCoversSourceTimeouts(t *testing.T) {
+	config := DefaultConfig()
+	for source, timeout := range config.SourceTimeouts {
+		if timeout.Duration > config.AllBudget.Duration {
+			t.Errorf("default %s timeout %v exceeds the default all_budget %v", source, timeout, config.AllBudget)
+		}
+	}
+}
+
+func TestValidateWarnsWhenSourceTimeoutExceedsBudget(t *testing.T) {
+	tests := []struct {
+		name     string
+		budget   time.Duration
+		wantWarn bool
+	}{
+		{name: "budget too short", budget: 30 * time.Second, wantWarn: true},
+		{name: "budget covers it", budget: time.Minute, wantWarn: false},
+		{name: "budget disabled", budget: 0, wantWarn: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logs := captureLogs(t, false)
+			config := DefaultConfig()
+			config.AllBudget = Duration{tc.budget}
+			config.SourceTimeouts = map[string]Duration{"sport365": {40 * time.Second}}
+			if err := config.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			warned := strings.Contains(logs.String(), "source_timeouts.sport365 (40s) exceeds all_budget")
+			if warned != tc.wantWarn {
+				t.Errorf("warned = %v, want %v; logs: %s", warned, tc.wantWarn, logs)
+			}
+		})
+	}
+}
+
+func TestTimeoutFor(t *testing.T) {
+	config, err := resolveArgs(t, "-timeout", "20s", "-source-timeouts", "sport365=60s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]time.Duration{
+		"hellotickets": 15 * time.Second, // Default kept when another source is overridden
+		"vividseats":   15 * time.Second,
+		"sport365":     60 * time.Second,
+		"unlisted":     20 * time.Second, // request_timeout
+	}
+	for source, timeout := range want {
+		if got := config.TimeoutFor(source); got != timeout {
+			t.Errorf("TimeoutFor(%q) = %v, want %v", source, got, timeout)
+		}
+		if got := config.ScraperOptionsFor(source).Timeout; got != timeout {
+			t.Errorf("ScraperOptionsFor(%q).Timeout = %v, want %v", source, got, timeout)
+		}
+	}
+}
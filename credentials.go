@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"normalizer/scraper"
+)
+
+// credentialsKey is the context key of caller-supplied scrape credentials
+type credentialsKey struct{}
+
+// withCredentials returns a copy of ctx carrying the caller's credentials for the scrape
+func withCredentials(ctx context.Context, creds scraper.Credentials) context.Context {
+	return context.WithValue(ctx, credentialsKey{}, creds)
+}
+
+// credentialsFrom returns the caller's credentials carried by ctx, if any
+func credentialsFrom(ctx context.Context) (scraper.Credentials, bool) {
+	creds, ok := ctx.Value(credentialsKey{}).(scraper.Credentials)
+	return creds, ok && !creds.IsZero()
+}
+
+// handleScrapeWithCredentials is POST /scrape: the same as GET /scrape, with
+// a body of cookies and basic auth to scrape a single source with, e.g. a
+// session for a page that needs a login. Such scrapes are never cached.
+// Only the source's listing requests carry the credentials; match detail
+// (/event) and custom scrapes never take any.
+func (ws *WebServer) handleScrapeWithCredentials(w http.ResponseWriter, r *http.Request) {
+	var creds scraper.Credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := creds.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	source := r.URL.Query().Get("source")
+	if source == "" {
+		source = ws.config.DefaultSource
+	}
+	info, ok := scraper.DefaultRegistry.Info(source)
+	if !ok || !info.Cookies {
+		http.Error(w, fmt.Sprintf("Credentials can only be sent to a single source: %s", strings.Join(credentialSources(), ", ")), http.StatusBadRequest)
+		return
+	}
+	if creds.BasicAuth != nil && !info.BasicAuth {
+		http.Error(w, fmt.Sprintf("basic_auth is not supported for %s", source), http.StatusBadRequest)
+		return
+	}
+
+	ws.handleScrape(w, r.WithContext(withCredentials(r.Context(), creds)))
+}
+
+// credentialSources returns the registered sources that accept caller credentials
+func credentialSources() []string {
+	var names []string
+	for _, info := range scraper.SourceInfos() {
+		if info.Cookies {
+			names = append(names, info.Name)
+		}
+	}
+	return names
+}
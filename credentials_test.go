@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestScrapeWithCredentialsRejects(t *testing.T) {
+	_, srv := newTestServer(t, nil)
+	tests := []struct {
+		name     string
+		query    string
+		body     string
+		wantBody string
+	}{
+		{
+			name:     "all sources",
+			query:    "?source=all",
+			body:     `{"cookies": [{"name": "session", "value": "abc"}]}`,
+			wantBody: "hellotickets, vividseats, sport365",
+		},
+		{
+			name:     "basic auth for a browser source",
+			query:    "?source=sport365",
+			body:     `{"basic_auth": {"username": "fan", "password": "secret"}}`,
+			wantBody: "basic_auth is not supported for sport365",
+		},
+		{
+			name:     "invalid cookie",
+			query:    "?source=hellotickets",
+			body:     `{"cookies": [{"name": "bad name", "value": "abc"}]}`,
+			wantBody: "invalid cookie",
+		},
+		{
+			name:     "malformed body",
+			query:    "?source=hellotickets",
+			body:     `{"cookies": `,
+			wantBody: "Invalid request body",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body := post(t, srv, "/scrape"+tc.query, tc.body)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", resp.StatusCode)
+			}
+			if !strings.Contains(body, tc.wantBody) {
+				t.Errorf("body = %q, want it to mention %q", body, tc.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"normalizer/scraper"
+)
+
+// filterChain holds the filters of a /scrape request. They always run in the
+// same order, so the result doesn't depend on the order of the query params;
+// the README's "Order of operations" lists it.
+type filterChain struct {
+	includeNonMatches bool
+	teamName          string // Canonical team name; resolved by the caller
+	keyword           string
+	pricedOnly        bool
+	flagPast          bool      // Mark events dated before today
+	excludePast       bool      // Also drop them
+	from, to          time.Time // Zero when the bound isn't given
+	withinDays        int       // -1 when not given
+	strictDates       bool      // Drop events with unreadable dates from a from/to range instead of keeping them
+	sortOrder         string    // "", "original", "price_asc" or "price_desc"
+	perSourceLimit    int       // 0 when unlimited
+	view              string    // "" or "next-per-competition"
+
+	sampleSize int   // -1 when not sampling
+	sampleSeed int64 // Seeds the sample, so the same seed picks the same events
+
+	matchesOnly   bool             // Keep only event names that look like fixtures
+	matchPattern  *regexp.Regexp   // What a fixture's name looks like; set by the caller from the config
+	allowPatterns []*regexp.Regexp // Event names to keep; empty keeps any
+	denyPatterns  []*regexp.Regexp // Event names to drop
+
+	competitions []string // Competitions to keep; empty keeps any
+}
+
+// newFilterChain builds the filter chain from the request params, reading from/to
+// dates in loc. The team param is left to the caller, which resolves its slug.
+func newFilterChain(query url.Values, loc *time.Location) (*filterChain, error) {
+	chain := &filterChain{
+		includeNonMatches: query.Get("includeNonMatches") == "true",
+		keyword:           query.Get("filter"),
+		pricedOnly:        query.Get("pricedOnly") == "true",
+		flagPast:          query.Get("flagPastEvents") == "true",
+		excludePast:       query.Get("excludePast") == "true",
+		strictDates:       query.Get("strictDates") == "true",
+		withinDays:        -1,
+		sampleSize:        -1,
+		matchesOnly:       query.Get("matchesOnly") == "true",
+	}
+
+	var err error
+	if chain.allowPatterns, err = compilePatterns("allowPatterns", query["allowPatterns"]); err != nil {
+		return nil, err
+	}
+	if chain.denyPatterns, err = compilePatterns("denyPatterns", query["denyPatterns"]); err != nil {
+		return nil, err
+	}
+
+	if competitions := query.Get("competitions"); competitions != "" {
+		for _, name := range strings.Split(competitions, ",") {
+			competition, ok := scraper.ResolveCompetition(strings.TrimSpace(name))
+			if !ok {
+				return nil, fmt.Errorf("Invalid competition: %s. Use: laliga, ucl, copadelrey, supercopa, or clubworldcup", name)
+			}
+			chain.competitions = append(chain.competitions, competition)
+		}
+	}
+
+	switch sortOrder := query.Get("sort"); sortOrder {
+	case "", "original", "price_asc", "price_desc":
+		chain.sortOrder = sortOrder
+	default:
+		return nil, errors.New("Invalid sort. Use: original, price_asc, or price_desc")
+	}
+
+	if withinDays := query.Get("withinDays"); withinDays != "" {
+		days, err := strconv.Atoi(withinDays)
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("Invalid withinDays: %s", withinDays)
+		}
+		chain.withinDays = days
+	}
+
+	switch view := query.Get("view"); view {
+	case "", "next-per-competition":
+		chain.view = view
+	default:
+		return nil, errors.New("Invalid view. Use: next-per-competition")
+	}
+
+	if limit := query.Get("perSourceLimit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("Invalid perSourceLimit: %s", limit)
+		}
+		chain.perSourceLimit = n
+	}
+
+	if sample := query.Get("sample"); sample != "" {
+		n, err := strconv.Atoi(sample)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("Invalid sample: %s", sample)
+		}
+		chain.sampleSize = n
+	}
+	if seed := query.Get("seed"); seed != "" {
+		n, err := strconv.ParseInt(seed, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid seed: %s", seed)
+		}
+		chain.sampleSeed = n
+	}
+
+	if dateFrom := query.Get("from"); dateFrom != "" {
+		from, err := time.ParseInLocation("2006-01-02", dateFrom, loc)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid from date: %s", dateFrom)
+		}
+		chain.from = from
+	}
+	if dateTo := query.Get("to"); dateTo != "" {
+		to, err := time.ParseInLocation("2006-01-02", dateTo, loc)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid to date: %s", dateTo)
+		}
+		chain.to = to
+	}
+
+	return chain, nil
+}
+
+// compilePatterns compiles the regular expressions given for the param, which may be repeated
+func compilePatterns(param string, values []string) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for _, value := range values {
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: %v", param, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// apply runs the filters over result in the chain's fixed order: team,
+// keyword and exclusions, competition, price, date, sort, then the paging
+// steps (per-source limit, sample and view)
+func (c *filterChain) apply(result *scraper.ScrapingResult, normalizer *scraper.TeamNameNormalizer) *scraper.ScrapingResult {
+	// Flagging comes first so the flags show whichever filters follow
+	if c.flagPast || c.excludePast {
+		result = result.FlagPastEvents()
+	}
+
+	if c.teamName != "" {
+		result = normalizer.FilterByTeam(result, c.teamName)
+	}
+
+	if c.keyword != "" {
+		result = result.FilterByKeyword(c.keyword)
+	}
+
+	// Parking and packages are listed like matches
+	if !c.includeNonMatches {
+		result = result.OnlyMatches()
+	}
+
+	// Tours and museum visits aren't typed, so they are told apart by name
+	if c.matchesOnly {
+		result = result.FilterMatchesOnly(c.matchPattern)
+	}
+	result = result.FilterByNamePatterns(c.allowPatterns, c.denyPatterns)
+
+	if len(c.competitions) > 0 {
+		result = result.FilterByCompetitions(c.competitions...)
+	}
+
+	if c.pricedOnly {
+		result = result.WithPriceOnly()
+	}
+
+	// An open-ended range reaches a year back or two years ahead
+	if !c.from.IsZero() || !c.to.IsZero() {
+		from, to := c.from, c.to
+		if from.IsZero() {
+			from = time.Now().AddDate(-1, 0, 0)
+		}
+		if to.IsZero() {
+			to = time.Now().AddDate(2, 0, 0)
+		}
+		result = result.FilterByDateRange(from, to, !c.strictDates)
+	}
+
+	if c.withinDays >= 0 {
+		result = result.FilterWithinDays(c.withinDays)
+	}
+
+	if c.excludePast {
+		result = result.WithoutPastEvents()
+	}
+
+	switch c.sortOrder {
+	case "original":
+		result = result.SortByOriginal()
+	case "price_asc":
+		result = result.SortByPrice(true)
+	case "price_desc":
+		result = result.SortByPrice(false)
+	}
+
+	// Capping after sorting keeps each source's first events in the requested order
+	if c.perSourceLimit > 0 {
+		result = result.LimitPerSource(c.perSourceLimit)
+	}
+
+	if c.sampleSize >= 0 {
+		result = result.Sample(c.sampleSize, c.sampleSeed)
+	}
+
+	if c.view == "next-per-competition" {
+		result = result.NextPerCompetition()
+	}
+
+	return result
+}
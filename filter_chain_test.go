@@ -0,0 +1,129 @@
+package main
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"normalizer/scraper"
+)
+
+// chainEvents runs the filter chain built from rawQuery over a fixed set of events
+func chainEvents(t *testing.T, rawQuery string) []string {
+	t.Helper()
+	query := mustParseQuery(t, rawQuery)
+	chain, err := newFilterChain(query, time.UTC)
+	if err != nil {
+		t.Fatalf("%s: %v", rawQuery, err)
+	}
+
+	normalizer := scraper.NewTeamNameNormalizer()
+	if team := query.Get("team"); team != "" {
+		var ok bool
+		if chain.teamName, ok = normalizer.ResolveTeamSlug(team); !ok {
+			t.Fatalf("unknown team %q", team)
+		}
+	}
+
+	result := &scraper.ScrapingResult{Events: []scraper.TicketEvent{
+		{Event: "Real Madrid vs Getafe", Source: "hellotickets", Competition: "La Liga", PriceValue: 90, DateTime: "2030-09-02"},
+		{Event: "Real Madrid vs Manchester City", Source: "hellotickets", Competition: "Champions League", PriceValue: 250, DateTime: "2030-09-20"},
+		{Event: "Real Madrid vs Sevilla", Source: "vividseats", Competition: "La Liga", PriceValue: 60, DateTime: "2030-10-07"},
+		{Event: "Real Madrid vs Alaves", Source: "vividseats", Competition: "La Liga", PriceValue: 40, DateTime: "2030-10-21"},
+		{Event: "Real Madrid vs Alaves Parking", Source: "vividseats", Competition: "La Liga", PriceValue: 10, DateTime: "2030-10-21", Type: scraper.ListingTypeParking},
+		{Event: "Barcelona vs Girona", Source: "vividseats", Competition: "La Liga", PriceValue: 30, DateTime: "2030-10-22"},
+		{Event: "Real Madrid vs Osasuna", Source: "vividseats", Competition: "La Liga", DateTime: "2030-11-04"},
+	}}
+	result.Total = len(result.Events)
+
+	var names []string
+	for _, event := range chain.apply(result, normalizer).Events {
+		names = append(names, event.Event)
+	}
+	return names
+}
+
+func TestFilterChainIgnoresParamOrder(t *testing.T) {
+	params := []string{
+		"team=real-madrid",
+		"filter=madrid",
+		"denyPatterns=Getafe",
+		"competitions=laliga",
+		"pricedOnly=true",
+		"from=2030-09-01",
+		"to=2030-12-31",
+		"sort=price_asc",
+		"perSourceLimit=1",
+	}
+
+	want := chainEvents(t, strings.Join(params, "&"))
+	if !slices.Equal(want, []string{"Real Madrid vs Alaves"}) {
+		t.Fatalf("chain kept %q, want the cheapest priced La Liga Real Madrid match per source", want)
+	}
+
+	reversed := slices.Clone(params)
+	slices.Reverse(reversed)
+	shuffled := []string{params[7], params[2], params[8], params[0], params[5], params[3], params[1], params[6], params[4]}
+	for _, order := range [][]string{reversed, shuffled} {
+		if got := chainEvents(t, strings.Join(order, "&")); !slices.Equal(got, want) {
+			t.Errorf("%s kept %q, want %q", strings.Join(order, "&"), got, want)
+		}
+	}
+}
+
+func TestFilterChainSortsBeforePaging(t *testing.T) {
+	// Capping runs after sorting, so each source keeps its cheapest events
+	// rather than the first ones listed
+	got := chainEvents(t, "perSourceLimit=1&sort=price_asc&pricedOnly=true")
+	if want := []string{"Barcelona vs Girona", "Real Madrid vs Getafe"}; !slices.Equal(got, want) {
+		t.Errorf("kept %q, want %q", got, want)
+	}
+}
+
+func TestFilterChainCheapestFirst(t *testing.T) {
+	// "Cheapest N" is a price sort followed by a cap; the unpriced Osasuna
+	// match sorts last and is capped away
+	got := chainEvents(t, "team=real-madrid&sort=price_asc&perSourceLimit=2")
+	want := []string{"Real Madrid vs Alaves", "Real Madrid vs Sevilla", "Real Madrid vs Getafe", "Real Madrid vs Manchester City"}
+	if !slices.Equal(got, want) {
+		t.Errorf("kept %q, want %q", got, want)
+	}
+}
+
+func TestFilterChainSample(t *testing.T) {
+	got := chainEvents(t, "sample=3&seed=42")
+	if len(got) != 3 {
+		t.Fatalf("kept %q, want 3 events", got)
+	}
+	if again := chainEvents(t, "seed=42&sample=3"); !slices.Equal(again, got) {
+		t.Errorf("seed 42 kept %q, then %q", got, again)
+	}
+
+	for _, query := range []string{"sample=-1", "sample=few", "sample=3&seed=x"} {
+		if _, err := newFilterChain(mustParseQuery(t, query), time.UTC); err == nil {
+			t.Errorf("%s: built a chain, want an error", query)
+		}
+	}
+}
+
+// mustParseQuery parses rawQuery, failing the test when it is malformed
+func mustParseQuery(t *testing.T, rawQuery string) url.Values {
+	t.Helper()
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return query
+}
+
+func TestFilterChainCompetitions(t *testing.T) {
+	got := chainEvents(t, "competitions=ucl")
+	if want := []string{"Real Madrid vs Manchester City"}; !slices.Equal(got, want) {
+		t.Errorf("kept %q, want %q", got, want)
+	}
+	if _, err := newFilterChain(mustParseQuery(t, "competitions=laliga,premier-division"), time.UTC); err == nil {
+		t.Error("built a chain with an unknown competition, want an error")
+	}
+}
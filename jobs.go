@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// jobTTL is how long a finished job's results stay available
+const jobTTL = time.Hour
+
+// Job statuses
+const (
+	jobRunning   = "running"
+	jobDone      = "done"
+	jobCancelled = "cancelled"
+)
+
+// asyncJob is a batch of scrape jobs running in the background
+type asyncJob struct {
+	ID         string           `json:"id"`
+	Status     string           `json:"status"`
+	Done       int              `json:"done"`  // Scrape jobs finished so far
+	Total      int              `json:"total"` // Scrape jobs in the batch
+	Results    []batchJobResult `json:"results,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+
+	cancel context.CancelFunc
+}
+
+// jobStore keeps async jobs in memory, dropping finished ones after jobTTL
+type jobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*asyncJob
+}
+
+// newJobStore creates an empty job store
+func newJobStore() *jobStore {
+	return &jobStore{jobs: make(map[string]*asyncJob)}
+}
+
+// add registers a new running job and returns it
+func (s *jobStore) add(total int, cancel context.CancelFunc) (*asyncJob, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate job ID: %w", err)
+	}
+
+	job := &asyncJob{
+		ID:        hex.EncodeToString(id),
+		Status:    jobRunning,
+		Total:     total,
+		Results:   make([]batchJobResult, total),
+		CreatedAt: time.Now(),
+		cancel:    cancel,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+	s.jobs[job.ID] = job
+	return job, nil
+}
+
+// snapshot returns a copy of the job that is safe to encode while it keeps running
+func (s *jobStore) snapshot(id string) (asyncJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return asyncJob{}, false
+	}
+	copied := *job
+	copied.Results = append([]batchJobResult(nil), job.Results...)
+	return copied, true
+}
+
+// update applies fn to the job while holding the store lock
+func (s *jobStore) update(id string, fn func(job *asyncJob)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if ok {
+		fn(job)
+	}
+	return ok
+}
+
+// prune drops finished jobs older than jobTTL. Callers must hold s.mu.
+func (s *jobStore) prune() {
+	cutoff := time.Now().Add(-jobTTL)
+	for id, job := range s.jobs {
+		if job.FinishedAt != nil && job.FinishedAt.Before(cutoff) {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// finish marks a job as finished with the given status unless it already finished
+func finish(job *asyncJob, status string) {
+	if job.FinishedAt != nil {
+		return
+	}
+	now := time.Now()
+	job.Status = status
+	job.FinishedAt = &now
+}
+
+// handleCreateJob starts a batch of scrape jobs in the background and returns its ID
+func (ws *WebServer) handleCreateJob(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Jobs) == 0 {
+		http.Error(w, "No jobs given", http.StatusBadRequest)
+		return
+	}
+	if len(req.Jobs) > maxBatchJobs {
+		http.Error(w, fmt.Sprintf("Too many jobs: %d (max %d)", len(req.Jobs), maxBatchJobs), http.StatusBadRequest)
+		return
+	}
+
+	// The job outlives the request, so it gets its own cancellable context
+	ctx, cancel := context.WithCancel(context.Background())
+	job, err := ws.jobs.add(len(req.Jobs), cancel)
+	if err != nil {
+		cancel()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	go ws.runAsyncJob(ctx, job.ID, req.Jobs)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"id": job.ID, "status": jobRunning})
+}
+
+// runAsyncJob runs the scrape jobs like a batch request, recording progress as each one finishes
+func (ws *WebServer) runAsyncJob(ctx context.Context, id string, jobs []batchJob) {
+	sem := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job batchJob) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result := ws.runBatchJob(ctx, job)
+			ws.jobs.update(id, func(j *asyncJob) {
+				j.Results[i] = result
+				j.Done++
+			})
+		}(i, job)
+	}
+	wg.Wait()
+
+	ws.jobs.update(id, func(j *asyncJob) {
+		finish(j, jobDone)
+		j.cancel()
+	})
+}
+
+// handleGetJob returns a job's status, progress and the results finished so far
+func (ws *WebServer) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := ws.jobs.snapshot(mux.Vars(r)["id"])
+	if !ok {
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// handleCancelJob cancels a running job; scrapes in flight stop and report their error
+func (ws *WebServer) handleCancelJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	found := ws.jobs.update(id, func(j *asyncJob) {
+		finish(j, jobCancelled)
+		j.cancel()
+	})
+	if !found {
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+
+	job, _ := ws.jobs.snapshot(id)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
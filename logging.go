@@ -0,0 +1,107 @@
+package main
+
+import (
+	"log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// setupLogging switches the standard logger to JSON lines when format is
+// "json". The scrapers log through the standard logger, so they follow suit.
+func setupLogging(format string) {
+	if format != "json" {
+		return
+	}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, nil)))
+}
+
+// statusRecorder wraps a ResponseWriter to remember the status code sent.
+// Handlers that only call Write send 200, which is the starting value.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader records the status code before writing it. Like net/http, only
+// the first call counts.
+func (rec *statusRecorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status = status
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Write marks the header as sent, so a later WriteHeader can't change the logged status
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	return rec.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController, e.g. for flushing
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// redactedValue replaces the value of a redacted query param in the access log
+const redactedValue = "REDACTED"
+
+// redactQuery masks the values of the given query params (matched
+// case-insensitively) in a request URI. The other params are kept as sent and
+// in their original order.
+func redactQuery(uri string, params map[string]bool) string {
+	path, query, found := strings.Cut(uri, "?")
+	if !found || len(params) == 0 {
+		return uri
+	}
+
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			name = key
+		}
+		if params[strings.ToLower(name)] {
+			pairs[i] = key + "=" + redactedValue
+		}
+	}
+	return path + "?" + strings.Join(pairs, "&")
+}
+
+// loggingMiddleware logs each request with its status and duration, as JSON
+// fields when jsonLogs is set and as a text line otherwise. The values of the
+// redact query params are masked.
+func loggingMiddleware(jsonLogs bool, redact []string) func(http.Handler) http.Handler {
+	redactSet := make(map[string]bool, len(redact))
+	for _, param := range redact {
+		redactSet[strings.ToLower(param)] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			duration := time.Since(start)
+			uri := redactQuery(r.RequestURI, redactSet)
+
+			if jsonLogs {
+				slog.Info("request",
+					"method", r.Method,
+					"path", uri,
+					"status", rec.status,
+					"duration_ms", duration.Milliseconds(),
+					"remote", r.RemoteAddr,
+				)
+				return
+			}
+			log.Printf("%s %s %d %s %v", r.Method, uri, rec.status, r.RemoteAddr, duration)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// captureLogs sends the log output to a buffer for the rest of the test, as
+// JSON lines when jsonLogs is set and as plain text lines otherwise
+func captureLogs(t *testing.T, jsonLogs bool) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+
+	prevOutput, prevFlags, prevDefault := log.Writer(), log.Flags(), slog.Default()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	if jsonLogs {
+		slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	}
+	t.Cleanup(func() {
+		log.SetOutput(prevOutput)
+		log.SetFlags(prevFlags)
+		slog.SetDefault(prevDefault)
+	})
+	return &buf
+}
+
+// serveLogged sends a GET of uri through the logging middleware to handler
+func serveLogged(jsonLogs bool, redact []string, uri string, handler http.HandlerFunc) {
+	req := httptest.NewRequest(http.MethodGet, uri, nil)
+	loggingMiddleware(jsonLogs, redact)(handler).ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestLoggingMiddlewareStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    int
+	}{
+		{"bad request", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Invalid source", http.StatusBadRequest)
+		}, http.StatusBadRequest},
+		{"write only", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		}, http.StatusOK},
+		{"nothing written", func(w http.ResponseWriter, r *http.Request) {}, http.StatusOK},
+		{"second WriteHeader", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.WriteHeader(http.StatusOK)
+		}, http.StatusBadGateway},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := captureLogs(t, false)
+			serveLogged(false, nil, "/scrape?source=x", tc.handler)
+			if line := buf.String(); !strings.HasPrefix(line, fmt.Sprintf("GET /scrape?source=x %d ", tc.want)) {
+				t.Errorf("logged %q, want status %d", line, tc.want)
+			}
+
+			buf = captureLogs(t, true)
+			serveLogged(true, nil, "/scrape?source=x", tc.handler)
+			var entry struct {
+				Status int `json:"status"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("JSON log %q: %v", buf.String(), err)
+			}
+			if entry.Status != tc.want {
+				t.Errorf("JSON log status = %d, want %d", entry.Status, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoggingMiddlewareRedactsParams(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	redact := []string{"apikey", "session"}
+
+	buf := captureLogs(t, false)
+	serveLogged(false, redact, "/scrape?source=all&APIKey=s3cr3t&filter=madrid&session=abc%20def", ok)
+	line := buf.String()
+	if strings.Contains(line, "s3cr3t") || strings.Contains(line, "abc") {
+		t.Errorf("logged %q, want the secrets masked", line)
+	}
+	if !strings.HasPrefix(line, "GET /scrape?source=all&APIKey="+redactedValue+"&filter=madrid&session="+redactedValue+" ") {
+		t.Errorf("logged %q, want the other params kept in order", line)
+	}
+
+	buf = captureLogs(t, true)
+	serveLogged(true, redact, "/scrape?apikey=s3cr3t", ok)
+	if line := buf.String(); strings.Contains(line, "s3cr3t") || !strings.Contains(line, redactedValue) {
+		t.Errorf("JSON log %q, want the key masked", line)
+	}
+
+	// Params not configured are left alone
+	buf = captureLogs(t, false)
+	serveLogged(false, nil, "/scrape?apikey=visible", ok)
+	if line := buf.String(); !strings.Contains(line, "apikey=visible") {
+		t.Errorf("logged %q with no params to redact", line)
+	}
+}
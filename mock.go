@@ -0,0 +1,27 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"normalizer/scraper"
+)
+
+// mockEvents is a fixed, realistic scrape served by source=mock
+//
+//go:embed fixtures/mock_events.json
+var mockEvents []byte
+
+// scrapeMock returns the embedded mock result, stamped with the current time.
+// Every call decodes a fresh copy, so the request pipeline can't alter it.
+func scrapeMock() (*scraper.ScrapingResult, error) {
+	var result scraper.ScrapingResult
+	if err := json.Unmarshal(mockEvents, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode mock events: %w", err)
+	}
+	result.Timestamp = time.Now()
+	result.Total = len(result.Events)
+	return &result, nil
+}
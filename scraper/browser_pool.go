@@ -0,0 +1,94 @@
+package scraper
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chromedp/chromedp"
+)
+
+// browserPool bounds how many Chrome instances the chromedp scrapers run at
+// once, and shares one exec allocator per browser language and User-Agent between them
+type browserPool struct {
+	mu         sync.Mutex
+	slots      chan struct{}
+	allocators map[string]execAllocator
+}
+
+// execAllocator is a shared chromedp exec allocator and the function that
+// shuts it down, along with any Chrome it started
+type execAllocator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// browsers is the pool used by every chromedp scrape in the process
+var browsers = newBrowserPool(1)
+
+// newBrowserPool creates a pool allowing n Chrome instances at once
+func newBrowserPool(n int) *browserPool {
+	return &browserPool{
+		slots:      make(chan struct{}, max(n, 1)),
+		allocators: make(map[string]execAllocator),
+	}
+}
+
+// SetMaxBrowsers sets how many Chrome instances may run at once across all
+// scrapers; further scrapes queue for a free slot. The default is 1.
+func SetMaxBrowsers(n int) {
+	browsers.mu.Lock()
+	defer browsers.mu.Unlock()
+	browsers.slots = make(chan struct{}, max(n, 1))
+}
+
+// CloseBrowsers shuts down the shared allocators and the Chrome instances they
+// started, e.g. when the server stops. Later scrapes start new ones.
+func CloseBrowsers() {
+	browsers.close()
+}
+
+// acquire waits for a free browser slot, returning the function that frees it,
+// or fails when ctx is done first
+func (p *browserPool) acquire(ctx context.Context) (release func(), err error) {
+	p.mu.Lock()
+	slots := p.slots
+	p.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// allocator returns the shared exec allocator for the given browser language
+// and User-Agent, creating it on first use. Allocators live until the pool is closed.
+func (p *browserPool) allocator(language, userAgent string) context.Context {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := language + "|" + userAgent
+	if alloc, ok := p.allocators[key]; ok {
+		return alloc.ctx
+	}
+
+	allocOpts := append(chromedp.DefaultExecAllocatorOptions[:], chromedp.UserAgent(userAgent))
+	if language != "" {
+		allocOpts = append(allocOpts, chromedp.Flag("lang", language), chromedp.Flag("accept-lang", language))
+	}
+	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), allocOpts...)
+	p.allocators[key] = execAllocator{ctx: allocCtx, cancel: cancel}
+	return allocCtx
+}
+
+// close cancels every allocator, which stops the browsers they started
+func (p *browserPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, alloc := range p.allocators {
+		alloc.cancel()
+		delete(p.allocators, key)
+	}
+}
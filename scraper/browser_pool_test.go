@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBrowserPoolLimitsConcurrency(t *testing.T) {
+	const limit = 2
+	pool := newBrowserPool(limit)
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := pool.acquire(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer release()
+
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != limit {
+		t.Errorf("peak concurrent browsers = %d, want %d", got, limit)
+	}
+}
+
+func TestBrowserPoolAcquireCancelled(t *testing.T) {
+	pool := newBrowserPool(1)
+	release, err := pool.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pool.acquire(ctx); err == nil {
+		t.Error("acquire succeeded with every slot taken")
+	}
+}
+
+func TestBrowserPoolCloseCancelsAllocators(t *testing.T) {
+	pool := newBrowserPool(1)
+	en := pool.allocator("en", DefaultUserAgent)
+	es := pool.allocator("es", DefaultUserAgent)
+	if pool.allocator("en", DefaultUserAgent) != en {
+		t.Error("allocator for the same language and User-Agent was not shared")
+	}
+
+	pool.close()
+	if en.Err() == nil || es.Err() == nil {
+		t.Error("allocators still running after close")
+	}
+	if fresh := pool.allocator("en", DefaultUserAgent); fresh.Err() != nil {
+		t.Error("allocator created after close is already cancelled")
+	}
+	pool.close()
+}
@@ -0,0 +1,45 @@
+package scraper
+
+// CollapseListings merges listings of the same match from the same source (e.g.
+// several VividSeats sellers) into one event carrying the cheapest price, the
+// price range and the number of listings. Unlike Deduplicate it never merges
+// across sources. Matches are keyed by teams and day, so normalize first.
+func (r *ScrapingResult) CollapseListings() *ScrapingResult {
+	collapsed := r.withEvents([]TicketEvent{})
+	index := make(map[string]int)
+
+	for _, event := range r.Events {
+		key := event.Source + "|" + eventKey(event)
+		i, exists := index[key]
+		if !exists {
+			index[key] = len(collapsed.Events)
+			event.ListingCount = 1
+			event.PriceMin, event.PriceMax = event.PriceValue, event.PriceValue
+			collapsed.Events = append(collapsed.Events, event)
+			continue
+		}
+
+		kept := &collapsed.Events[i]
+		kept.ListingCount++
+		if event.PriceValue <= 0 {
+			continue
+		}
+
+		// The collapsed event shows its cheapest listing
+		if kept.PriceValue <= 0 || event.PriceValue < kept.PriceValue {
+			kept.Price = event.Price
+			kept.PriceValue = event.PriceValue
+			kept.Currency = event.Currency
+			kept.Link = event.Link
+		}
+		if kept.PriceMin <= 0 || event.PriceValue < kept.PriceMin {
+			kept.PriceMin = event.PriceValue
+		}
+		if event.PriceValue > kept.PriceMax {
+			kept.PriceMax = event.PriceValue
+		}
+	}
+
+	collapsed.Total = len(collapsed.Events)
+	return collapsed
+}
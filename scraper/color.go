@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// ANSI escape codes used by FormatAsColorTable
+const (
+	ansiBold  = "\033[1m"
+	ansiDim   = "\033[2m"
+	ansiReset = "\033[0m"
+)
+
+// tableStyles holds the style of each tableRows column: event names are bold
+// and links dimmed
+var tableStyles = []string{"", ansiBold, "", ansiDim, ""}
+
+// FormatAsColorTable formats the scraping results like FormatAsTable, with the
+// event names in bold and the links dimmed for an interactive terminal. Without
+// color the output is the same as FormatAsTable's, with no escape codes.
+func (r *ScrapingResult) FormatAsColorTable(color bool) string {
+	if !color {
+		return r.FormatAsTable()
+	}
+	rows := r.tableRows()
+
+	// Pad by hand, since tabwriter would count the escape codes as text
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var sb strings.Builder
+	for n, row := range rows {
+		for i, cell := range row {
+			padding := ""
+			if i < len(row)-1 {
+				padding = strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2)
+			}
+			// Header rows stay plain
+			if n > 1 && tableStyles[i] != "" {
+				cell = tableStyles[i] + cell + ansiReset
+			}
+			sb.WriteString(cell + padding)
+		}
+		fmt.Fprintln(&sb)
+	}
+	return sb.String()
+}
+
+// useColor reports whether output to w should be colored: only when w is a
+// terminal and NO_COLOR isn't set, so piped output stays plain for parsing
+func useColor(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
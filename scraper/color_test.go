@@ -0,0 +1,73 @@
+package scraper
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func colorTestResult() *ScrapingResult {
+	return &ScrapingResult{Events: []TicketEvent{
+		{DateTime: "27 Sep Sat 4:15pm", Event: "Atlético de Madrid vs. Real Madrid CF", Price: "From €95", Link: "https://example.com/1", Source: "hellotickets"},
+		{DateTime: "26 Oct", Event: "Real Madrid vs. Barcelona", Link: "https://example.com/2", Source: "sport365"},
+	}}
+}
+
+func TestNoColorWhenNotATerminal(t *testing.T) {
+	result := colorTestResult()
+
+	var buf bytes.Buffer
+	if err := (WriterSink{W: &buf}).Write(result, "table"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("table written to a buffer has escape codes: %q", buf.String())
+	}
+
+	// A file isn't a terminal either, as when stdout is redirected
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if useColor(f) {
+		t.Error("useColor reported a regular file as a terminal")
+	}
+	if err := (WriterSink{W: f}).Write(result, "txt"); err != nil {
+		t.Fatal(err)
+	}
+	written, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != result.FormatAsTable() {
+		t.Errorf("table written to a file = %q, want the plain table", written)
+	}
+}
+
+func TestNoColorEnvDisablesColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if useColor(os.Stdout) {
+		t.Error("useColor with NO_COLOR set")
+	}
+}
+
+func TestColorTableLayout(t *testing.T) {
+	result := colorTestResult()
+	if plain := result.FormatAsColorTable(false); plain != result.FormatAsTable() {
+		t.Errorf("FormatAsColorTable(false) = %q, want FormatAsTable's output", plain)
+	}
+
+	colored := result.FormatAsColorTable(true)
+	if !strings.Contains(colored, ansiBold+"Real Madrid vs. Barcelona"+ansiReset) || !strings.Contains(colored, ansiDim+"https://example.com/1"+ansiReset) {
+		t.Errorf("colored table %q lacks the bold names and dimmed links", colored)
+	}
+
+	// Without the escape codes the layout, PRICE column included, is the plain table's
+	stripped := strings.NewReplacer(ansiBold, "", ansiDim, "", ansiReset, "").Replace(colored)
+	if stripped != result.FormatAsTable() {
+		t.Errorf("colored table without escape codes =\n%s\nwant\n%s", stripped, result.FormatAsTable())
+	}
+}
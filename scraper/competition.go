@@ -0,0 +1,134 @@
+package scraper
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// CompetitionOther groups events whose competition couldn't be detected
+const CompetitionOther = "other"
+
+// competitionKeywords maps each competition to the lowercase, accent-free
+// phrases that identify it, checked in order so the more specific names win
+var competitionKeywords = []struct {
+	name     string
+	keywords []string
+}{
+	{"Champions League", []string{"champions league", "uefa champions"}},
+	{"Club World Cup", []string{"club world cup", "mundial de clubes"}},
+	{"Supercopa", []string{"supercopa", "super cup"}},
+	{"Copa del Rey", []string{"copa del rey", "kings cup", "king's cup"}},
+	{"La Liga", []string{"la liga", "laliga", "liga ea sports", "primera division"}},
+}
+
+// competitionAliases maps short codes accepted for a competition to its name
+var competitionAliases = map[string]string{
+	"ucl": "Champions League",
+	"cwc": "Club World Cup",
+	"cdr": "Copa del Rey",
+}
+
+// competitionKey folds a competition name for comparison, so "LaLiga",
+// "la liga" and "La Liga" are the same
+func competitionKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(foldAccents(name)), " ", "")
+}
+
+// ResolveCompetition returns the competition a name or code such as "laliga"
+// or "ucl" stands for, reporting false when it isn't a known competition
+func ResolveCompetition(name string) (string, bool) {
+	key := competitionKey(name)
+	if alias, ok := competitionAliases[key]; ok {
+		return alias, true
+	}
+	for _, competition := range competitionKeywords {
+		if competitionKey(competition.name) == key {
+			return competition.name, true
+		}
+	}
+	return "", false
+}
+
+// FilterByCompetitions keeps events whose detected competition is one of
+// allowed, given by name or code (see ResolveCompetition) in any case. Events
+// with no detected competition, such as friendlies, are dropped. An empty
+// allowlist keeps every event.
+func (r *ScrapingResult) FilterByCompetitions(allowed ...string) *ScrapingResult {
+	if len(allowed) == 0 {
+		return r
+	}
+
+	keep := make(map[string]bool)
+	for _, name := range allowed {
+		if competition, ok := ResolveCompetition(name); ok {
+			keep[competition] = true
+		}
+	}
+
+	events := []TicketEvent{}
+	for _, event := range r.Events {
+		if keep[event.Competition] {
+			events = append(events, event)
+		}
+	}
+	return r.withEvents(events)
+}
+
+// detectCompetition returns the competition named in text, or "" if there is none
+func detectCompetition(text string) string {
+	folded := strings.ToLower(foldAccents(text))
+	for _, competition := range competitionKeywords {
+		for _, keyword := range competition.keywords {
+			if strings.Contains(folded, keyword) {
+				return competition.name
+			}
+		}
+	}
+	return ""
+}
+
+// NextPerCompetition keeps the nearest upcoming event of each competition, for
+// a "what's next" view. Events without a competition share the "other" bucket,
+// and events without a readable date are dropped. The result is ordered by date.
+func (r *ScrapingResult) NextPerCompetition() *ScrapingResult {
+	// Event dates are parsed without a zone, so today is in the same naive UTC terms
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	type candidate struct {
+		event TicketEvent
+		date  time.Time
+	}
+	next := make(map[string]candidate)
+	for _, event := range r.Events {
+		date, err := parseEventDate(event.DateTime)
+		if err != nil || date.Before(today) {
+			continue
+		}
+		competition := event.Competition
+		if competition == "" {
+			competition = CompetitionOther
+		}
+		if current, ok := next[competition]; !ok || date.Before(current.date) {
+			next[competition] = candidate{event, date}
+		}
+	}
+
+	candidates := make([]candidate, 0, len(next))
+	for _, c := range next {
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].date.Equal(candidates[j].date) {
+			return candidates[i].date.Before(candidates[j].date)
+		}
+		return candidates[i].event.OriginalIndex < candidates[j].event.OriginalIndex
+	})
+
+	events := make([]TicketEvent, len(candidates))
+	for i, c := range candidates {
+		events[i] = c.event
+	}
+	return r.withEvents(events)
+}
@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNextPerCompetition(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{
+		{Event: "La Liga later", Competition: "La Liga", DateTime: "2099-10-07"},
+		{Event: "Champions League next", Competition: "Champions League", DateTime: "2099-09-17", OriginalIndex: 1},
+		{Event: "La Liga next", Competition: "La Liga", DateTime: "2099-09-02", OriginalIndex: 2},
+		{Event: "La Liga past", Competition: "La Liga", DateTime: "2020-09-02", OriginalIndex: 3},
+		{Event: "friendly next", DateTime: "2099-08-10", OriginalIndex: 4},
+		{Event: "friendly later", DateTime: "2099-08-20", OriginalIndex: 5},
+		{Event: "Copa undated", Competition: "Copa del Rey", DateTime: "TBD", OriginalIndex: 6},
+		{Event: "Champions League later", Competition: "Champions League", DateTime: "2099-11-05", OriginalIndex: 7},
+	}}
+
+	next := result.NextPerCompetition()
+	if want := []string{"friendly next", "La Liga next", "Champions League next"}; !slices.Equal(eventNames(next), want) {
+		t.Errorf("NextPerCompetition() = %q, want %q", eventNames(next), want)
+	}
+	if next.Total != 3 {
+		t.Errorf("Total = %d, want 3", next.Total)
+	}
+}
+
+func TestDetectCompetition(t *testing.T) {
+	for text, want := range map[string]string{
+		"Real Madrid vs Getafe - LaLiga EA Sports":       "La Liga",
+		"UEFA Champions League: Real Madrid vs Juventus": "Champions League",
+		"Real Madrid vs Barcelona (Supercopa de España)": "Supercopa",
+		"Copa del Rey: Real Madrid vs Valencia":          "Copa del Rey",
+		"Real Madrid vs AC Milan (Friendly)":             "",
+	} {
+		if got := detectCompetition(text); got != want {
+			t.Errorf("detectCompetition(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestFilterByCompetitions(t *testing.T) {
+	var result ScrapingResult
+	for _, name := range []string{
+		"Real Madrid vs Getafe - LaLiga EA Sports",
+		"UEFA Champions League: Real Madrid vs Juventus",
+		"Real Madrid vs AC Milan (Friendly)",
+		"Copa del Rey: Real Madrid vs Valencia",
+		"Real Madrid vs Sevilla",
+	} {
+		result.Events = append(result.Events, TicketEvent{Event: name, Competition: detectCompetition(name)})
+	}
+	result.Total = len(result.Events)
+
+	want := []string{"Real Madrid vs Getafe - LaLiga EA Sports", "UEFA Champions League: Real Madrid vs Juventus"}
+	for _, allowed := range [][]string{{"laliga", "ucl"}, {"La Liga", "CHAMPIONS LEAGUE"}} {
+		filtered := result.FilterByCompetitions(allowed...)
+		// The friendly and the match with no detected competition are both dropped
+		if got := eventNames(filtered); !slices.Equal(got, want) {
+			t.Errorf("FilterByCompetitions(%q) = %q, want %q", allowed, got, want)
+		}
+		if filtered.Total != 2 {
+			t.Errorf("FilterByCompetitions(%q) Total = %d, want 2", allowed, filtered.Total)
+		}
+	}
+
+	if all := result.FilterByCompetitions(); all.Total != 5 {
+		t.Errorf("an empty allowlist kept %d events, want all 5", all.Total)
+	}
+}
+
+func TestResolveCompetition(t *testing.T) {
+	for name, want := range map[string]string{
+		"laliga":           "La Liga",
+		"LA LIGA":          "La Liga",
+		"ucl":              "Champions League",
+		"copa del rey":     "Copa del Rey",
+		"premier division": "",
+	} {
+		got, ok := ResolveCompetition(name)
+		if got != want || ok != (want != "") {
+			t.Errorf("ResolveCompetition(%q) = %q, %v; want %q", name, got, ok, want)
+		}
+	}
+}
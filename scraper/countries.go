@@ -0,0 +1,37 @@
+package scraper
+
+// getNationalTeamMappings returns country names and their common English,
+// Spanish, French, German, Italian and Portuguese forms, keyed by the folded,
+// lower-case alias and mapped to the English name
+func getNationalTeamMappings() map[string]string {
+	return map[string]string{
+		// Spain
+		"spain": "Spain", "espana": "Spain", "espagne": "Spain", "spanien": "Spain", "spagna": "Spain", "espanha": "Spain",
+		// France
+		"france": "France", "francia": "France", "frankreich": "France", "franca": "France",
+		// Germany
+		"germany": "Germany", "alemania": "Germany", "allemagne": "Germany", "deutschland": "Germany", "germania": "Germany", "alemanha": "Germany",
+		// Italy
+		"italy": "Italy", "italia": "Italy", "italie": "Italy", "italien": "Italy",
+		// Portugal
+		"portugal": "Portugal", "portogallo": "Portugal",
+		// England
+		"england": "England", "inglaterra": "England", "angleterre": "England", "inghilterra": "England",
+		// Netherlands
+		"netherlands": "Netherlands", "holland": "Netherlands", "paises bajos": "Netherlands", "holanda": "Netherlands", "pays-bas": "Netherlands", "niederlande": "Netherlands", "paesi bassi": "Netherlands",
+		// Belgium
+		"belgium": "Belgium", "belgica": "Belgium", "belgique": "Belgium", "belgien": "Belgium", "belgio": "Belgium",
+		// Croatia
+		"croatia": "Croatia", "croacia": "Croatia", "croatie": "Croatia", "kroatien": "Croatia", "croazia": "Croatia", "hrvatska": "Croatia",
+		// Brazil
+		"brazil": "Brazil", "brasil": "Brazil", "bresil": "Brazil", "brasilien": "Brazil", "brasile": "Brazil",
+		// Argentina
+		"argentina": "Argentina", "argentine": "Argentina", "argentinien": "Argentina",
+		// Morocco
+		"morocco": "Morocco", "marruecos": "Morocco", "maroc": "Morocco", "marokko": "Morocco", "marocco": "Morocco",
+		// United States
+		"united states": "United States", "usa": "United States", "estados unidos": "United States", "etats-unis": "United States", "usmnt": "United States",
+		// Mexico
+		"mexico": "Mexico", "mexique": "Mexico", "mexiko": "Mexico", "messico": "Mexico",
+	}
+}
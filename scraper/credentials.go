@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Credentials are cookies and basic-auth credentials a caller supplies for a
+// scrape, e.g. a session for a listing page that needs a login. They are only
+// sent to the scraped source's own domain.
+type Credentials struct {
+	Cookies   []Cookie   `json:"cookies,omitempty"`
+	BasicAuth *BasicAuth `json:"basic_auth,omitempty"`
+}
+
+// Cookie is a cookie to send with a scrape's requests
+type Cookie struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// BasicAuth is a username and password sent as HTTP basic auth
+type BasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// IsZero reports whether no cookies or basic auth are given
+func (c Credentials) IsZero() bool {
+	return len(c.Cookies) == 0 && c.BasicAuth == nil
+}
+
+// Validate checks that every cookie has a valid name and value and that basic auth has a username
+func (c Credentials) Validate() error {
+	for _, cookie := range c.Cookies {
+		if err := (&http.Cookie{Name: cookie.Name, Value: cookie.Value}).Valid(); err != nil {
+			return fmt.Errorf("invalid cookie %q: %w", cookie.Name, err)
+		}
+	}
+	if c.BasicAuth != nil && (c.BasicAuth.Username == "" || strings.Contains(c.BasicAuth.Username, ":")) {
+		return fmt.Errorf("basic_auth needs a username without ':'")
+	}
+	return nil
+}
+
+// apply adds the credentials to the headers of a request to u, unless u is
+// off the source's domain, e.g. a redirect to another site
+func (c Credentials) apply(headers *http.Header, u *url.URL, source string) {
+	if c.IsZero() || !onSourceDomain(u, source) {
+		return
+	}
+
+	if len(c.Cookies) > 0 {
+		pairs := make([]string, len(c.Cookies))
+		for i, cookie := range c.Cookies {
+			pairs[i] = (&http.Cookie{Name: cookie.Name, Value: cookie.Value}).String()
+		}
+		addCookies(headers, pairs...)
+	}
+	if c.BasicAuth != nil {
+		auth := c.BasicAuth.Username + ":" + c.BasicAuth.Password
+		headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(auth)))
+	}
+}
+
+// addCookies appends name=value pairs to the request's Cookie header, which
+// must stay a single header
+func addCookies(headers *http.Header, pairs ...string) {
+	if existing := headers.Get("Cookie"); existing != "" {
+		pairs = append([]string{existing}, pairs...)
+	}
+	headers.Set("Cookie", strings.Join(pairs, "; "))
+}
+
+// onSourceDomain reports whether u is on the source's domain or one of its subdomains
+func onSourceDomain(u *url.URL, source string) bool {
+	domain, ok := sourceDomain(source)
+	if !ok || u == nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
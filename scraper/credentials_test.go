@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCredentialsSentWithListingRequest(t *testing.T) {
+	opts := testOptions()
+	opts.Credentials = Credentials{
+		Cookies:   []Cookie{{Name: "session", Value: "abc123"}, {Name: "consent", Value: "yes"}},
+		BasicAuth: &BasicAuth{Username: "fan", Password: "secret"},
+	}
+	s := NewScraperWithOptions(opts)
+
+	var cookie, username, password string
+	var hasAuth bool
+	mockSite(t, s.collector, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie = r.Header.Get("Cookie")
+		username, password, hasAuth = r.BasicAuth()
+		w.Write([]byte("<html><body><ul></ul></body></html>"))
+	}))
+
+	if _, err := s.Scrape(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(cookie, "session=abc123; consent=yes") {
+		t.Errorf("Cookie header = %q, want the supplied cookies", cookie)
+	}
+	if !hasAuth || username != "fan" || password != "secret" {
+		t.Errorf("basic auth = %q/%q (sent %v), want fan/secret", username, password, hasAuth)
+	}
+}
+
+func TestCredentialsWithheldFromOtherDomains(t *testing.T) {
+	creds := Credentials{
+		Cookies:   []Cookie{{Name: "session", Value: "abc123"}},
+		BasicAuth: &BasicAuth{Username: "fan", Password: "secret"},
+	}
+	tests := []struct {
+		url  string
+		sent bool
+	}{
+		{url: "https://www.hellotickets.com/real-madrid-tickets", sent: true},
+		{url: "https://hellotickets.com/", sent: true},
+		{url: "https://evil.example/hellotickets.com", sent: false},
+		{url: "https://nothellotickets.com/", sent: false},
+	}
+
+	for _, tc := range tests {
+		u, err := url.Parse(tc.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		headers := http.Header{}
+		creds.apply(&headers, u, "hellotickets")
+		sent := headers.Get("Cookie") != "" || headers.Get("Authorization") != ""
+		if sent != tc.sent {
+			t.Errorf("credentials sent to %s = %v, want %v", tc.url, sent, tc.sent)
+		}
+	}
+}
+
+func TestCredentialsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		creds   Credentials
+		wantErr bool
+	}{
+		{name: "cookies", creds: Credentials{Cookies: []Cookie{{Name: "session", Value: "abc"}}}},
+		{name: "bad cookie name", creds: Credentials{Cookies: []Cookie{{Name: "bad name", Value: "abc"}}}, wantErr: true},
+		{name: "no username", creds: Credentials{BasicAuth: &BasicAuth{Password: "secret"}}, wantErr: true},
+		{name: "colon in username", creds: Credentials{BasicAuth: &BasicAuth{Username: "a:b"}}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.creds.Validate(); (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package scraper
+
+import (
+	"cmp"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date ranges of multi-day events such as tournaments, day first ("27 - 29 Sep",
+// "30 Dec 2025 - 2 Jan 2026") or month first ("Sep 27-29", "Sep 27 to Oct 2 2025").
+// Either side may leave out what it shares with the other.
+var (
+	dayFirstRangePattern   = regexp.MustCompile(`^(\d{1,2})(?:\s+([A-Z][a-z]{2}))?(?:\s+(\d{4}))?\s*(?:-|–|—|to)\s*(\d{1,2})\s+([A-Z][a-z]{2})(?:\s+(\d{4}))?$`)
+	monthFirstRangePattern = regexp.MustCompile(`^([A-Z][a-z]{2})\s+(\d{1,2})(?:\s+(\d{4}))?\s*(?:-|–|—|to)\s*(?:([A-Z][a-z]{2})\s+)?(\d{1,2})(?:\s+(\d{4}))?$`)
+)
+
+// eventDayLayout is the layout of StartDate and EndDate
+const eventDayLayout = "2006-01-02"
+
+// splitDateRange reads a date range, returning its first day written like the
+// listing's other dates along with the start and end as StartDate/EndDate
+// values. Text that isn't a range comes back unchanged with empty dates.
+func splitDateRange(text string) (first, startDate, endDate string) {
+	var startParts, endParts []string
+	if m := dayFirstRangePattern.FindStringSubmatch(text); m != nil {
+		startMonth, startYear := cmp.Or(m[2], m[5]), cmp.Or(m[3], m[6])
+		startParts = nonEmpty(m[1], startMonth, startYear)
+		endParts = nonEmpty(m[4], m[5], m[6])
+	} else if m := monthFirstRangePattern.FindStringSubmatch(text); m != nil {
+		endMonth, startYear := cmp.Or(m[4], m[1]), cmp.Or(m[3], m[6])
+		startParts = nonEmpty(m[1], m[2], startYear)
+		endParts = nonEmpty(endMonth, m[5], m[6])
+	} else {
+		return text, "", ""
+	}
+
+	start, err := parseEventDate(padDay(startParts))
+	if err != nil {
+		return text, "", ""
+	}
+	end, err := parseEventDate(padDay(endParts))
+	if err != nil {
+		return text, "", ""
+	}
+
+	// A range given without years, or with only the end's, that crosses New
+	// Year's or is already under way reads its start a year too late
+	if end.Before(start) {
+		start = start.AddDate(-1, 0, 0)
+		if last := len(startParts) - 1; len(startParts[last]) == 4 {
+			startParts[last] = strconv.Itoa(start.Year())
+		}
+	}
+	return strings.Join(startParts, " "), start.Format(eventDayLayout), end.Format(eventDayLayout)
+}
+
+// padDay joins date parts for parseEventDate, whose layouts want two-digit days
+func padDay(parts []string) string {
+	padded := make([]string, len(parts))
+	for i, part := range parts {
+		if len(part) == 1 && part[0] >= '0' && part[0] <= '9' {
+			part = "0" + part
+		}
+		padded[i] = part
+	}
+	return strings.Join(padded, " ")
+}
+
+// firstOfRange returns the first part of a range such as "Fri - Sun"
+func firstOfRange(text string) string {
+	for _, sep := range []string{"-", "–", "—"} {
+		if before, _, found := strings.Cut(text, sep); found {
+			return strings.TrimSpace(before)
+		}
+	}
+	return text
+}
+
+// dateSpan returns the first and last instant in loc of a multi-day event,
+// reporting false for events without a readable StartDate/EndDate
+func (e TicketEvent) dateSpan(loc *time.Location) (start, end time.Time, ok bool) {
+	if e.StartDate == "" || e.EndDate == "" {
+		return time.Time{}, time.Time{}, false
+	}
+	start, err := time.ParseInLocation(eventDayLayout, e.StartDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	last, err := time.ParseInLocation(eventDayLayout, e.EndDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, last.AddDate(0, 0, 1).Add(-time.Nanosecond), true
+}
@@ -0,0 +1,60 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitDateRange(t *testing.T) {
+	tests := []struct {
+		text      string
+		first     string
+		startDate string
+		endDate   string
+	}{
+		{text: "27 - 29 Sep 2026", first: "27 Sep 2026", startDate: "2026-09-27", endDate: "2026-09-29"},
+		{text: "30 Dec 2025 - 2 Jan 2026", first: "30 Dec 2025", startDate: "2025-12-30", endDate: "2026-01-02"},
+		{text: "Dec 30 - Jan 2 2026", first: "Dec 30 2025", startDate: "2025-12-30", endDate: "2026-01-02"},
+		{text: "Sep 27 to Oct 2 2026", first: "Sep 27 2026", startDate: "2026-09-27", endDate: "2026-10-02"},
+		{text: "27 Sep 2026", first: "27 Sep 2026"},
+	}
+
+	for _, tc := range tests {
+		first, startDate, endDate := splitDateRange(tc.text)
+		if first != tc.first || startDate != tc.startDate || endDate != tc.endDate {
+			t.Errorf("splitDateRange(%q) = %q, %q, %q; want %q, %q, %q",
+				tc.text, first, startDate, endDate, tc.first, tc.startDate, tc.endDate)
+		}
+	}
+}
+
+func TestFilterByDateMultiDayOverlap(t *testing.T) {
+	// A tournament from 28 Jun to 3 Jul, filtered by windows in a zone ahead of
+	// UTC, where its last day ends at 3 Jul 22:00 UTC
+	madrid := time.FixedZone("CEST", 2*60*60)
+	result := &ScrapingResult{Events: []TicketEvent{
+		{Event: "Summer Cup", DateTime: "28 Jun 2026", StartDate: "2026-06-28", EndDate: "2026-07-03"},
+	}}
+	day := func(d, h int) time.Time { return time.Date(2026, time.July, d, h, 0, 0, 0, madrid) }
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     bool
+	}{
+		{name: "window straddles the end", from: day(1, 0), to: day(10, 0), want: true},
+		{name: "window starts on the last day", from: day(3, 12), to: day(10, 0), want: true},
+		{name: "window ends on the first day", from: day(-10, 0), to: time.Date(2026, time.June, 28, 9, 0, 0, 0, madrid), want: true},
+		{name: "window starts the day after", from: day(4, 0), to: day(10, 0), want: false},
+		{name: "window ends the day before", from: day(-10, 0), to: time.Date(2026, time.June, 27, 23, 59, 0, 0, madrid), want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kept := result.FilterByDate(tc.from, tc.to).Total == 1
+			if kept != tc.want {
+				t.Errorf("kept = %v, want %v", kept, tc.want)
+			}
+		})
+	}
+}
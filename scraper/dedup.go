@@ -0,0 +1,108 @@
+package scraper
+
+import (
+	"strings"
+	"time"
+)
+
+// eventKey identifies the same match across listings: the teams plus the calendar day
+func eventKey(event TicketEvent) string {
+	teams := event.Event
+	if event.HomeTeam != "" && event.AwayTeam != "" {
+		teams = event.HomeTeam + "|" + event.AwayTeam
+	}
+	teams = strings.Join(strings.Fields(strings.ToLower(foldAccents(teams))), " ")
+
+	day := strings.ToLower(strings.TrimSpace(event.DateTime))
+	if eventDate, err := parseEventDate(event.DateTime); err == nil {
+		day = eventDate.Format("2006-01-02")
+	}
+
+	return teams + "|" + day
+}
+
+// mergeSourceNames combines comma-separated source lists without duplicates
+func mergeSourceNames(a, b string) string {
+	sources := eventSources(TicketEvent{Source: a})
+	for _, source := range eventSources(TicketEvent{Source: b}) {
+		found := false
+		for _, existing := range sources {
+			if existing == source {
+				found = true
+				break
+			}
+		}
+		if !found {
+			sources = append(sources, source)
+		}
+	}
+	return strings.Join(sources, ",")
+}
+
+// Deduplicate collapses events describing the same match (same teams and day),
+// keeping the first listing and recording every source that listed it.
+// Normalizing first gives much better matches across sources.
+func (r *ScrapingResult) Deduplicate() *ScrapingResult {
+	deduped := r.withEvents([]TicketEvent{})
+	index := make(map[string]int)
+
+	for _, event := range r.Events {
+		key := eventKey(event)
+		if i, exists := index[key]; exists {
+			deduped.Events[i].Source = mergeSourceNames(deduped.Events[i].Source, event.Source)
+			continue
+		}
+		index[key] = len(deduped.Events)
+		deduped.Events = append(deduped.Events, event)
+	}
+
+	deduped.Total = len(deduped.Events)
+	deduped.recordDedup(r)
+	return deduped
+}
+
+// recordDedup updates the raw count and duplicate counter after collapsing events from before
+func (r *ScrapingResult) recordDedup(before *ScrapingResult) {
+	if before.RawTotal == 0 {
+		r.RawTotal = len(before.Events)
+	}
+	r.DuplicatesRemoved = before.DuplicatesRemoved + len(before.Events) - len(r.Events)
+}
+
+// MergeResults combines several results into one and collapses duplicate matches
+func MergeResults(results ...*ScrapingResult) *ScrapingResult {
+	merged := &ScrapingResult{
+		Events: []TicketEvent{},
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		merged.Events = append(merged.Events, result.Events...)
+		merged.Source = mergeSourceNames(merged.Source, result.Source)
+		merged.AddSourceURL(result)
+		merged.AddSkips(result)
+		merged.RawTotal += rawTotal(result)
+		merged.DuplicatesRemoved += result.DuplicatesRemoved
+		if merged.Timestamp.IsZero() || (!result.Timestamp.IsZero() && result.Timestamp.Before(merged.Timestamp)) {
+			merged.Timestamp = result.Timestamp
+		}
+	}
+
+	if merged.Timestamp.IsZero() {
+		merged.Timestamp = time.Now()
+	}
+	merged.SourceURL = "multiple_sources"
+	merged.Total = len(merged.Events)
+
+	return merged.Deduplicate()
+}
+
+// rawTotal returns the pre-dedup event count of a result
+func rawTotal(r *ScrapingResult) int {
+	if r.RawTotal > 0 {
+		return r.RawTotal
+	}
+	return len(r.Events)
+}
@@ -0,0 +1,434 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+	"golang.org/x/time/rate"
+)
+
+// sourceDomain returns the domain the source's pages are served from, as registered in DefaultRegistry
+func sourceDomain(source string) (string, bool) {
+	info, ok := DefaultRegistry.Info(source)
+	return info.Domain, ok && info.Domain != ""
+}
+
+// ValidateSourceURL checks that pageURL is an absolute http(s) URL on the source's domain
+func ValidateSourceURL(source, pageURL string) error {
+	domain, ok := sourceDomain(source)
+	if !ok {
+		return fmt.Errorf("unknown source %q", source)
+	}
+
+	parsed, err := url.Parse(pageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid URL %q: must be an absolute http(s) URL", pageURL)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host != domain && !strings.HasSuffix(host, "."+domain) {
+		return fmt.Errorf("URL %q is not on %s's domain %s", pageURL, source, domain)
+	}
+
+	return nil
+}
+
+// DetailScraper scrapes a single match page of a known source. It never leaves
+// the source's domain, whether by the URL it is given or by a redirect.
+type DetailScraper struct {
+	collector  *colly.Collector
+	source     string
+	captureRaw bool
+	language   string
+	limiter    *rate.Limiter
+}
+
+// NewDetailScraper creates a match page scraper for the given source
+func NewDetailScraper(source string, opts ScraperOptions) (*DetailScraper, error) {
+	if _, ok := sourceDomain(source); !ok {
+		return nil, fmt.Errorf("unknown source %q", source)
+	}
+
+	// Scrapes reuse the collector's clones, which share its visited-URL store
+	c := colly.NewCollector(
+		colly.UserAgent(opts.userAgent()),
+		colly.AllowURLRevisit(),
+	)
+
+	// Set up rate limiting to be respectful
+	c.Limit(&colly.LimitRule{
+		DomainGlob:  "*",
+		Parallelism: 1,
+		Delay:       opts.RequestDelay,
+	})
+	c.SetRequestTimeout(opts.Timeout)
+
+	// Redirects are followed like the listing scrapers do, but only within the source's domain
+	follow := redirectHandler(opts.maxRedirects(), opts.UnexpectedRedirects)
+	c.SetRedirectHandler(func(req *http.Request, via []*http.Request) error {
+		if !onSourceDomain(req.URL, source) {
+			return fmt.Errorf("redirect to %s leaves %s's domain", req.URL, source)
+		}
+		return follow(req, via)
+	})
+
+	s := &DetailScraper{
+		collector:  c,
+		source:     source,
+		captureRaw: opts.CaptureRaw,
+		language:   opts.Language,
+		limiter:    opts.Limiter,
+	}
+	s.onRequest(c)
+
+	return s, nil
+}
+
+// onRequest makes every outbound request of c draw from the global request
+// budget and carry the configured language, and drops requests off the source's domain
+func (s *DetailScraper) onRequest(c *colly.Collector) {
+	c.OnRequest(func(r *colly.Request) {
+		if !onSourceDomain(r.URL, s.source) {
+			log.Printf("Not fetching %s: it is not on %s's domain", r.URL, s.source)
+			r.Abort()
+			return
+		}
+		if err := waitLimiter(c.Context, s.limiter); err != nil {
+			r.Abort()
+			return
+		}
+		setLanguage(r.Headers, r.URL, s.source, s.language)
+	})
+}
+
+// ScrapeMatchDetail scrapes the match page at pageURL into a single event
+func (s *DetailScraper) ScrapeMatchDetail(pageURL string) (*TicketEvent, error) {
+	return s.ScrapeMatchDetailContext(context.Background(), pageURL)
+}
+
+// ScrapeMatchDetailContext scrapes the match page at pageURL into a single event,
+// aborting when ctx is done. The URL must be on the scraper's source domain.
+func (s *DetailScraper) ScrapeMatchDetailContext(ctx context.Context, pageURL string) (*TicketEvent, error) {
+	if err := ValidateSourceURL(s.source, pageURL); err != nil {
+		return nil, err
+	}
+
+	// Each scrape gets its own clone, since the callbacks hold per-scrape state;
+	// clones share the connections and cookie jar of the long-lived collector
+	c := s.collector.Clone()
+	s.onRequest(c)
+
+	var event *TicketEvent
+	c.OnHTML("html", func(e *colly.HTMLElement) {
+		event = parseMatchDetail(e.DOM, e.Request.URL.String(), s.source, s.captureRaw)
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("Error scraping %s: %v", r.Request.URL, err)
+	})
+
+	c.Context = ctx
+	if err := c.Visit(pageURL); err != nil {
+		return nil, newFetchError(s.source, pageURL, fmt.Errorf("failed to visit URL: %w", err))
+	}
+
+	if event == nil {
+		return nil, newScrapeError(s.source, pageURL, ErrorKindParse, fmt.Errorf("no event details found on page"))
+	}
+	return event, nil
+}
+
+// parseMatchDetail reads a match page, preferring the schema.org event data
+// ticket sites embed for search engines and falling back to the page title
+func parseMatchDetail(doc *goquery.Selection, pageURL, source string, captureRaw bool) *TicketEvent {
+	event := &TicketEvent{Link: pageURL, Source: source, ScrapedAt: time.Now()}
+
+	doc.Find("script[type='application/ld+json']").EachWithBreak(func(_ int, script *goquery.Selection) bool {
+		if data, ok := findSchemaEvent(script.Text()); ok {
+			data.apply(event, pageURL)
+			return false
+		}
+		return true
+	})
+
+	if event.Event == "" {
+		event.Event = cleanText(doc.Find("meta[property='og:title']").AttrOr("content", ""))
+	}
+	if event.Event == "" {
+		event.Event = cleanText(doc.Find("h1").First().Text())
+	}
+	if event.Event == "" {
+		return nil
+	}
+
+	if event.ImageURL == "" {
+		event.ImageURL = resolveURL(pageURL, doc.Find("meta[property='og:image']").AttrOr("content", ""))
+	}
+	if event.DateTime == "" {
+		event.Status = EventStatusDateTBD
+	}
+	event.Round = extractRound(event.Event)
+	event.Competition = detectCompetition(event.Event)
+	event.RawHTML = rawHTML(doc, captureRaw)
+
+	return event
+}
+
+// schemaEvent is the subset of a schema.org Event (or SportsEvent) a match page describes
+type schemaEvent struct {
+	Type      schemaTypes     `json:"@type"`
+	Name      string          `json:"name"`
+	StartDate string          `json:"startDate"`
+	Status    string          `json:"eventStatus"`
+	Image     json.RawMessage `json:"image"`
+	Location  json.RawMessage `json:"location"`
+	Offers    json.RawMessage `json:"offers"`
+	HomeTeam  schemaNamed     `json:"homeTeam"`
+	AwayTeam  schemaNamed     `json:"awayTeam"`
+}
+
+// schemaOffer is a schema.org Offer or AggregateOffer
+type schemaOffer struct {
+	Price         schemaNumber `json:"price"`
+	LowPrice      schemaNumber `json:"lowPrice"`
+	HighPrice     schemaNumber `json:"highPrice"`
+	PriceCurrency string       `json:"priceCurrency"`
+	Availability  string       `json:"availability"`
+	OfferCount    schemaNumber `json:"offerCount"`
+}
+
+// schemaNamed is any schema.org thing that only matters for its name
+type schemaNamed struct {
+	Name string `json:"name"`
+}
+
+// schemaTypes accepts @type as either a single type or a list of types
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// isEvent reports whether the type is Event or one of its subtypes such as SportsEvent
+func (t schemaTypes) isEvent() bool {
+	for _, name := range t {
+		if strings.HasSuffix(name, "Event") {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaNumber accepts numbers that sites write either as JSON numbers or as strings
+type schemaNumber float64
+
+func (n *schemaNumber) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "" || text == "null" {
+		return nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		// Not a number we can use, e.g. "Free" or "varies"; leave it unset
+		return nil
+	}
+	*n = schemaNumber(value)
+	return nil
+}
+
+// findSchemaEvent looks for an event in a JSON-LD script, which may hold a
+// single object, a list of objects or an @graph of them
+func findSchemaEvent(script string) (schemaEvent, bool) {
+	var nodes []json.RawMessage
+	trimmed := strings.TrimSpace(script)
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &nodes); err != nil {
+			return schemaEvent{}, false
+		}
+	} else {
+		var graph struct {
+			Graph []json.RawMessage `json:"@graph"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &graph); err != nil {
+			return schemaEvent{}, false
+		}
+		nodes = append(graph.Graph, json.RawMessage(trimmed))
+	}
+
+	for _, node := range nodes {
+		var event schemaEvent
+		if err := json.Unmarshal(node, &event); err == nil && event.Type.isEvent() && event.Name != "" {
+			return event, true
+		}
+	}
+	return schemaEvent{}, false
+}
+
+// apply copies the event data onto a TicketEvent
+func (d schemaEvent) apply(event *TicketEvent, pageURL string) {
+	event.Event = cleanText(d.Name)
+	event.DateTime = cleanText(d.StartDate)
+	event.HomeTeam = strings.TrimSpace(d.HomeTeam.Name)
+	event.AwayTeam = strings.TrimSpace(d.AwayTeam.Name)
+	event.Venue = schemaVenue(d.Location)
+	event.ImageURL = resolveURL(pageURL, schemaImage(d.Image))
+
+	offers := schemaOffers(d.Offers)
+	for _, offer := range offers {
+		low := float64(offer.LowPrice)
+		if low == 0 {
+			low = float64(offer.Price)
+		}
+		high := float64(offer.HighPrice)
+		if high == 0 {
+			high = low
+		}
+
+		if low > 0 && (event.PriceMin == 0 || Price(low) < event.PriceMin) {
+			event.PriceMin = Price(low)
+		}
+		if Price(high) > event.PriceMax {
+			event.PriceMax = Price(high)
+		}
+		if event.Currency == "" {
+			event.Currency = strings.ToUpper(offer.PriceCurrency)
+		}
+		// The match is as available as its most available offer
+		if availability := schemaAvailability(offer.Availability); availabilityRank[availability] > availabilityRank[event.Availability] {
+			event.Availability = availability
+		}
+		event.ListingCount += int(offer.OfferCount)
+	}
+
+	event.PriceValue = event.PriceMin
+	if event.PriceMin > 0 {
+		event.Price = strings.Join(nonEmpty("From", event.PriceMin.String(), event.Currency), " ")
+	}
+
+	// Cancelled and postponed matches are flagged on the event itself rather than the offers
+	switch schemaEnum(d.Status) {
+	case "EventCancelled":
+		event.Availability = AvailabilityCancelled
+	case "EventPostponed", "EventRescheduled":
+		event.Status = EventStatusDateTBD
+	}
+}
+
+// Availability values reported for match pages
+const (
+	AvailabilityAvailable = "available"
+	AvailabilityLimited   = "limited"
+	AvailabilitySoldOut   = "sold_out"
+	AvailabilityCancelled = "cancelled"
+)
+
+// availabilityRank orders the offer availabilities from least to most available
+var availabilityRank = map[string]int{
+	AvailabilitySoldOut:   1,
+	AvailabilityLimited:   2,
+	AvailabilityAvailable: 3,
+}
+
+// schemaAvailability maps a schema.org ItemAvailability to one of the Availability values
+func schemaAvailability(value string) string {
+	switch schemaEnum(value) {
+	case "":
+		return ""
+	case "SoldOut", "OutOfStock", "Discontinued":
+		return AvailabilitySoldOut
+	case "LimitedAvailability", "OnlineOnly":
+		return AvailabilityLimited
+	default:
+		return AvailabilityAvailable
+	}
+}
+
+// schemaEnum strips the schema.org prefix from an enumeration value, e.g.
+// "https://schema.org/InStock" becomes "InStock"
+func schemaEnum(value string) string {
+	value = strings.TrimSpace(value)
+	if i := strings.LastIndex(value, "/"); i >= 0 {
+		value = value[i+1:]
+	}
+	return value
+}
+
+// schemaOffers reads offers given as a single offer or a list of them
+func schemaOffers(raw json.RawMessage) []schemaOffer {
+	if len(raw) == 0 {
+		return nil
+	}
+	var list []schemaOffer
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	var single schemaOffer
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []schemaOffer{single}
+	}
+	return nil
+}
+
+// schemaVenue reads a location given as plain text or as a Place with an address
+func schemaVenue(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+
+	var place struct {
+		Name    string          `json:"name"`
+		Address json.RawMessage `json:"address"`
+	}
+	if err := json.Unmarshal(raw, &place); err != nil {
+		return ""
+	}
+
+	city := ""
+	var address struct {
+		Locality string `json:"addressLocality"`
+	}
+	if err := json.Unmarshal(place.Address, &address); err == nil {
+		city = address.Locality
+	}
+
+	return strings.Join(nonEmpty(strings.TrimSpace(place.Name), strings.TrimSpace(city)), " • ")
+}
+
+// schemaImage reads an image given as a URL, a list of URLs or an ImageObject
+func schemaImage(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil && len(list) > 0 {
+		return list[0]
+	}
+	var object struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(raw, &object); err == nil {
+		return object.URL
+	}
+	return ""
+}
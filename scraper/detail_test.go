@@ -0,0 +1,79 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// detailPage serves a match page named after its path, e.g. /match/3 is "Match 3"
+var detailPage = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if target := r.URL.Query().Get("redirect"); target != "" {
+		http.Redirect(w, r, target, http.StatusFound)
+		return
+	}
+	name := "Match " + strings.TrimPrefix(r.URL.Path, "/match/")
+	fmt.Fprintf(w, "<html><head><meta property=\"og:title\" content=\"%s\"></head><body></body></html>", name)
+})
+
+func newTestDetailScraper(t *testing.T) *DetailScraper {
+	t.Helper()
+	s, err := NewDetailScraper("vividseats", testOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockSite(t, s.collector, detailPage)
+	return s
+}
+
+func TestDetailScraperConcurrentCalls(t *testing.T) {
+	s := newTestDetailScraper(t)
+
+	// Each call sees only its own page, however many run at once or ran before
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			event, err := s.ScrapeMatchDetailContext(context.Background(), fmt.Sprintf("https://www.vividseats.com/match/%d", i%5))
+			if err != nil {
+				errs <- err
+				return
+			}
+			if want := fmt.Sprintf("Match %d", i%5); event.Event != want {
+				errs <- fmt.Errorf("call %d got %q, want %q", i, event.Event, want)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestDetailScraperStaysOnSourceDomain(t *testing.T) {
+	s := newTestDetailScraper(t)
+
+	if _, err := s.ScrapeMatchDetail("https://evil.example.com/match/1"); err == nil {
+		t.Error("scraped a URL off the source's domain")
+	}
+
+	// Redirects within the domain are followed
+	event, err := s.ScrapeMatchDetail("https://www.vividseats.com/match/1?redirect=https://vividseats.com/match/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Event != "Match 2" {
+		t.Errorf("got %q after an on-domain redirect, want Match 2", event.Event)
+	}
+
+	// Redirects anywhere else are not
+	if event, err := s.ScrapeMatchDetail("https://www.vividseats.com/match/1?redirect=http://169.254.169.254/latest/meta-data/"); err == nil {
+		t.Errorf("followed a redirect off the source's domain and got %+v", event)
+	}
+}
@@ -0,0 +1,95 @@
+package scraper
+
+import (
+	"reflect"
+	"time"
+)
+
+// IdentityFunc returns the key under which an event is tracked between scrapes;
+// events with the same key in both results are considered the same event
+type IdentityFunc func(TicketEvent) string
+
+// IdentityByTeamsDate treats listings of the same match on the same day as one event,
+// which suits tracking the fixture list
+func IdentityByTeamsDate(event TicketEvent) string {
+	return eventKey(event)
+}
+
+// IdentityByLink treats every listing link as its own event, which suits
+// tracking individual listings such as their prices
+func IdentityByLink(event TicketEvent) string {
+	return event.Link
+}
+
+// EventChange is an event present in both results whose details changed
+type EventChange struct {
+	Before TicketEvent `json:"before"`
+	After  TicketEvent `json:"after"`
+}
+
+// DiffResult lists what changed between two scrapes
+type DiffResult struct {
+	Added   []TicketEvent `json:"added"`
+	Removed []TicketEvent `json:"removed"`
+	Changed []EventChange `json:"changed"`
+}
+
+// Diff compares the previous result with the current one, matching events by
+// identityFn. A nil identityFn uses IdentityByTeamsDate. A change in position on
+// the source page alone is not reported.
+func Diff(previous, current *ScrapingResult, identityFn IdentityFunc) DiffResult {
+	if identityFn == nil {
+		identityFn = IdentityByTeamsDate
+	}
+
+	diff := DiffResult{
+		Added:   []TicketEvent{},
+		Removed: []TicketEvent{},
+		Changed: []EventChange{},
+	}
+
+	before := make(map[string]TicketEvent)
+	for _, event := range previous.Events {
+		key := identityFn(event)
+		if _, exists := before[key]; !exists {
+			before[key] = event
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, event := range current.Events {
+		key := identityFn(event)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		old, exists := before[key]
+		if !exists {
+			diff.Added = append(diff.Added, event)
+			continue
+		}
+		if !sameDetails(old, event) {
+			diff.Changed = append(diff.Changed, EventChange{Before: old, After: event})
+		}
+	}
+
+	reported := make(map[string]bool)
+	for _, event := range previous.Events {
+		key := identityFn(event)
+		if !seen[key] && !reported[key] {
+			reported[key] = true
+			diff.Removed = append(diff.Removed, event)
+		}
+	}
+
+	return diff
+}
+
+// sameDetails reports whether two events are equal apart from their position
+// on the page and when they were scraped
+func sameDetails(a, b TicketEvent) bool {
+	a.OriginalIndex, b.OriginalIndex = 0, 0
+	a.ScrapedAt, b.ScrapedAt = time.Time{}, time.Time{}
+	return reflect.DeepEqual(a, b)
+}
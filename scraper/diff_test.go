@@ -0,0 +1,47 @@
+package scraper
+
+import "testing"
+
+func TestDiffIdentityFunctions(t *testing.T) {
+	previous := &ScrapingResult{Events: []TicketEvent{
+		{Event: "Real Madrid vs Getafe", DateTime: "27 Sep 2025", Link: "https://example.com/a", Price: "€100"},
+		{Event: "Real Madrid vs Alaves", DateTime: "04 Oct 2025", Link: "https://example.com/c", Price: "€90"},
+	}}
+	// The Getafe match was relisted under a new link at a new price
+	current := &ScrapingResult{Events: []TicketEvent{
+		{Event: "Real Madrid vs Getafe", DateTime: "27 Sep 2025", Link: "https://example.com/b", Price: "€120"},
+		{Event: "Real Madrid vs Alaves", DateTime: "04 Oct 2025", Link: "https://example.com/c", Price: "€90"},
+	}}
+
+	byMatch := Diff(previous, current, IdentityByTeamsDate)
+	if len(byMatch.Added) != 0 || len(byMatch.Removed) != 0 || len(byMatch.Changed) != 1 {
+		t.Fatalf("by teams+date: got %d added, %d removed, %d changed; want 0, 0, 1",
+			len(byMatch.Added), len(byMatch.Removed), len(byMatch.Changed))
+	}
+	if change := byMatch.Changed[0]; change.Before.Price != "€100" || change.After.Price != "€120" {
+		t.Errorf("by teams+date: change is %q -> %q", change.Before.Price, change.After.Price)
+	}
+
+	byLink := Diff(previous, current, IdentityByLink)
+	if len(byLink.Added) != 1 || len(byLink.Removed) != 1 || len(byLink.Changed) != 0 {
+		t.Fatalf("by link: got %d added, %d removed, %d changed; want 1, 1, 0",
+			len(byLink.Added), len(byLink.Removed), len(byLink.Changed))
+	}
+	if byLink.Added[0].Link != "https://example.com/b" || byLink.Removed[0].Link != "https://example.com/a" {
+		t.Errorf("by link: added %q, removed %q", byLink.Added[0].Link, byLink.Removed[0].Link)
+	}
+}
+
+func TestDiffDefaultsToTeamsDate(t *testing.T) {
+	previous := &ScrapingResult{Events: []TicketEvent{
+		{Event: "Real Madrid vs Getafe", DateTime: "27 Sep 2025", Link: "https://example.com/a"},
+	}}
+	current := &ScrapingResult{Events: []TicketEvent{
+		{Event: "Real Madrid vs Getafe", DateTime: "27 Sep 2025", Link: "https://example.com/b"},
+	}}
+
+	diff := Diff(previous, current, nil)
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Changed) != 1 {
+		t.Errorf("got %d added, %d removed, %d changed; want 0, 0, 1", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	}
+}
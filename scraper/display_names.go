@@ -0,0 +1,56 @@
+package scraper
+
+import "strings"
+
+// SourceDisplayName returns the name people know a source by, e.g. "Sport365"
+// for "sport365". Merged sources such as "hellotickets,vividseats" are named
+// one by one; unknown IDs are returned unchanged.
+func SourceDisplayName(source string) string {
+	return displaySourceName(source, defaultDisplayNames())
+}
+
+// defaultDisplayNames maps each registered source ID to its display name
+func defaultDisplayNames() map[string]string {
+	infos := SourceInfos()
+	names := make(map[string]string, len(infos))
+	for _, info := range infos {
+		names[info.Name] = info.DisplayName
+	}
+	return names
+}
+
+// displaySourceName names each component source with names, keeping IDs it has no name for
+func displaySourceName(source string, names map[string]string) string {
+	ids := eventSources(TicketEvent{Source: source})
+	if len(ids) == 0 {
+		return source
+	}
+	for i, id := range ids {
+		if name := names[id]; name != "" {
+			ids[i] = name
+		}
+	}
+	return strings.Join(ids, ", ")
+}
+
+// WithDisplayNames returns a copy whose table, summary and HTML output show
+// sources by display name, while JSON keeps the stable IDs. names overrides
+// the built-in display names by source ID and may be nil.
+func (r *ScrapingResult) WithDisplayNames(names map[string]string) *ScrapingResult {
+	displayNames := defaultDisplayNames()
+	for id, name := range names {
+		displayNames[id] = name
+	}
+
+	labelled := *r
+	labelled.displayNames = displayNames
+	return &labelled
+}
+
+// sourceLabel returns how the human-oriented formats show a source
+func (r *ScrapingResult) sourceLabel(source string) string {
+	if r.displayNames == nil {
+		return source
+	}
+	return displaySourceName(source, r.displayNames)
+}
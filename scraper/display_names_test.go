@@ -0,0 +1,57 @@
+package scraper
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDisplayNamesInTableNotJSON(t *testing.T) {
+	result := &ScrapingResult{
+		Source: "hellotickets,sport365",
+		Events: []TicketEvent{
+			{DateTime: "27 Sep", Event: "Real Madrid vs Getafe", Link: "https://example.com/1", Source: "hellotickets"},
+			{DateTime: "28 Sep", Event: "Sevilla vs Betis", Link: "https://example.com/2", Source: "sport365"},
+		},
+		Total: 2,
+	}
+	labelled := result.WithDisplayNames(map[string]string{"sport365": "Sport 365 Live"})
+
+	table := labelled.FormatAsTable()
+	for _, name := range []string{"HelloTickets", "Sport 365 Live"} {
+		if !strings.Contains(table, name) {
+			t.Errorf("table doesn't show %q:\n%s", name, table)
+		}
+	}
+	if strings.Contains(table, "hellotickets") {
+		t.Errorf("table still shows the hellotickets ID:\n%s", table)
+	}
+	if plain := result.FormatAsTable(); !strings.Contains(plain, "hellotickets") {
+		t.Errorf("the original result's table lost its IDs:\n%s", plain)
+	}
+
+	data, err := json.Marshal(labelled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ScrapingResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Source != "hellotickets,sport365" || decoded.Events[0].Source != "hellotickets" || decoded.Events[1].Source != "sport365" {
+		t.Errorf("JSON sources = %q, %q, %q; want the IDs", decoded.Source, decoded.Events[0].Source, decoded.Events[1].Source)
+	}
+}
+
+func TestSourceDisplayName(t *testing.T) {
+	tests := map[string]string{
+		"sport365":                "Sport365",
+		"hellotickets,vividseats": "HelloTickets, VividSeats",
+		"ticketmaster":            "ticketmaster",
+	}
+	for source, want := range tests {
+		if got := SourceDisplayName(source); got != want {
+			t.Errorf("SourceDisplayName(%q) = %q, want %q", source, got, want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package scraper
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// emptyResults are a scrape that found nothing, with the events slice both
+// empty and unset, as results decoded from JSON may have it
+func emptyResults() map[string]*ScrapingResult {
+	scraped := time.Date(2025, 9, 20, 10, 0, 0, 0, time.UTC)
+	return map[string]*ScrapingResult{
+		"empty": {Events: []TicketEvent{}, Source: "vividseats", SourceURL: "https://www.vividseats.com/x", Timestamp: scraped},
+		"nil":   {Source: "vividseats", SourceURL: "https://www.vividseats.com/x", Timestamp: scraped},
+	}
+}
+
+func TestEmptyResultFilters(t *testing.T) {
+	for name, result := range emptyResults() {
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(1, 0, 0)
+		for filter, filtered := range map[string]*ScrapingResult{
+			"FilterByKeyword":   result.FilterByKeyword("madrid"),
+			"FilterByDate":      result.FilterByDate(from, to),
+			"FilterByDateRange": result.FilterByDateRange(from, to, false),
+		} {
+			if filtered.Total != 0 || len(filtered.Events) != 0 {
+				t.Errorf("%s: %s gave %d events, total %d", name, filter, len(filtered.Events), filtered.Total)
+			}
+		}
+	}
+}
+
+func TestEmptyResultNormalize(t *testing.T) {
+	n := NewTeamNameNormalizer()
+	for name, result := range emptyResults() {
+		normalized := n.NormalizeScrapingResult(result)
+		if normalized.Total != 0 || len(normalized.Events) != 0 {
+			t.Errorf("%s: normalized result has %d events, total %d", name, len(normalized.Events), normalized.Total)
+		}
+		if normalized.Source != result.Source {
+			t.Errorf("%s: normalized source = %q, want %q", name, normalized.Source, result.Source)
+		}
+	}
+}
+
+func TestEmptyResultSummary(t *testing.T) {
+	for name, result := range emptyResults() {
+		want := "No events found at https://www.vividseats.com/x (scraped at 2025-09-20 10:00:00)"
+		if got := result.GetSummary(); got != want {
+			t.Errorf("%s: GetSummary() = %q, want %q", name, got, want)
+		}
+	}
+
+	if got := (&ScrapingResult{}).GetSummary(); got != "No events found" {
+		t.Errorf("GetSummary() without a source URL = %q", got)
+	}
+}
+
+func TestEmptyResultFormats(t *testing.T) {
+	for name, result := range emptyResults() {
+		out, err := result.FormatAsJSON(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded map[string]any
+		if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if events, ok := decoded["events"].([]any); !ok || len(events) != 0 {
+			t.Errorf("%s: JSON events = %#v, want []", name, decoded["events"])
+		}
+
+		if links := result.FormatAsLinks(); links != "" {
+			t.Errorf("%s: FormatAsLinks() = %q, want empty", name, links)
+		}
+		if table := result.FormatAsTable(); !strings.Contains(table, "EVENT") {
+			t.Errorf("%s: FormatAsTable() = %q, want at least the header", name, table)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ErrorKind classifies why a scrape failed
+type ErrorKind string
+
+const (
+	ErrorKindNetwork ErrorKind = "network" // Connection failures and unexpected HTTP errors
+	ErrorKindTimeout ErrorKind = "timeout" // The request or the scrape budget ran out
+	ErrorKindBlocked ErrorKind = "blocked" // The site refused us, e.g. 403 or 429
+	ErrorKindParse   ErrorKind = "parse"   // The page was fetched but couldn't be read
+)
+
+// ScrapeError is returned by all scrapers so callers can tell which source failed and why
+type ScrapeError struct {
+	Source string    `json:"source"`
+	URL    string    `json:"url"`
+	Kind   ErrorKind `json:"kind"`
+	Err    error     `json:"-"`
+}
+
+// newScrapeError wraps err with its source, URL and kind
+func newScrapeError(source, url string, kind ErrorKind, err error) *ScrapeError {
+	return &ScrapeError{Source: source, URL: url, Kind: kind, Err: err}
+}
+
+// newFetchError wraps an error from fetching a page, classifying it as a timeout, block or network failure
+func newFetchError(source, url string, err error) *ScrapeError {
+	return newScrapeError(source, url, fetchErrorKind(err), err)
+}
+
+func (e *ScrapeError) Error() string {
+	return fmt.Sprintf("%s: %s error scraping %s: %v", e.Source, e.Kind, e.URL, e.Err)
+}
+
+func (e *ScrapeError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON includes the underlying error message alongside the attribution fields
+func (e ScrapeError) MarshalJSON() ([]byte, error) {
+	type attribution ScrapeError
+	message := ""
+	if e.Err != nil {
+		message = e.Err.Error()
+	}
+	return json.Marshal(struct {
+		attribution
+		Error string `json:"error"`
+	}{attribution(e), message})
+}
+
+// fetchErrorKind classifies a fetch error. Colly reports HTTP failures by their
+// status text, so blocks are recognised from the message.
+func fetchErrorKind(err error) ErrorKind {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorKindTimeout
+	}
+
+	// Being bounced to a login or region page means the site won't serve us the listings
+	if errors.Is(err, ErrUnexpectedRedirect) {
+		return ErrorKindBlocked
+	}
+
+	message := err.Error()
+	for _, status := range []string{"Forbidden", "Too Many Requests", "Unauthorized"} {
+		if strings.Contains(message, status) {
+			return ErrorKindBlocked
+		}
+	}
+	return ErrorKindNetwork
+}
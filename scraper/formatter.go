@@ -2,8 +2,11 @@ package scraper
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
-	"os"
+	"html/template"
+	"maps"
+	"slices"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -14,21 +17,50 @@ func (r *ScrapingResult) FormatAsTable() string {
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
 
-	// Header
-	fmt.Fprintf(w, "DATETIME\tEVENT\tLINK\tSOURCE\n")
-	fmt.Fprintf(w, "--------\t-----\t----\t------\n")
+	for _, row := range r.tableRows() {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	w.Flush()
+	return sb.String()
+}
+
+// tableRows returns the cells of FormatAsTable: two header rows, then one row per event
+func (r *ScrapingResult) tableRows() [][]string {
+	rows := [][]string{
+		{"DATETIME", "EVENT", "PRICE", "LINK", "SOURCE"},
+		{"--------", "-----", "-----", "----", "------"},
+	}
 
-	// Data rows
 	for _, event := range r.Events {
 		// Truncate long fields for better display
-		eventName := truncate(event.Event, 50)
-		link := truncate(event.Link, 60)
-
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+		rows = append(rows, []string{
 			event.DateTime,
-			eventName,
-			link,
-			event.Source,
+			truncate(event.Event, 50),
+			truncate(event.Price, 15),
+			truncate(event.Link, 60),
+			r.sourceLabel(event.Source),
+		})
+	}
+	return rows
+}
+
+// FormatAsCompactTable formats the scraping results as a table without links,
+// sized to fit an 80-column terminal
+func (r *ScrapingResult) FormatAsCompactTable() string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+
+	// Header
+	fmt.Fprintf(w, "DATETIME\tEVENT\tSOURCE\n")
+	fmt.Fprintf(w, "--------\t-----\t------\n")
+
+	// Data rows
+	for _, event := range r.Events {
+		fmt.Fprintf(w, "%s\t%s\t%s\n",
+			truncate(event.DateTime, 20),
+			truncate(event.Event, 40),
+			truncate(r.sourceLabel(event.Source), 12),
 		)
 	}
 
@@ -38,6 +70,11 @@ func (r *ScrapingResult) FormatAsTable() string {
 
 // FormatAsJSON formats the scraping results as JSON
 func (r *ScrapingResult) FormatAsJSON(indent bool) (string, error) {
+	// Unset events are written as [] like no events, so clients can always iterate them
+	if r.Events == nil {
+		r = r.withEvents([]TicketEvent{})
+	}
+
 	var data []byte
 	var err error
 
@@ -54,44 +91,194 @@ func (r *ScrapingResult) FormatAsJSON(indent bool) (string, error) {
 	return string(data), nil
 }
 
-// SaveToFile saves the results to a file in the specified format
-func (r *ScrapingResult) SaveToFile(filename, format string) error {
-	var content string
-	var err error
+// FormatAsLinks formats the scraping results as one link per line, in event
+// order, skipping empty and repeated links
+func (r *ScrapingResult) FormatAsLinks() string {
+	var sb strings.Builder
+	seen := make(map[string]bool)
 
-	switch strings.ToLower(format) {
-	case "json":
-		content, err = r.FormatAsJSON(true)
-		if err != nil {
-			return err
+	for _, event := range r.Events {
+		if event.Link == "" || seen[event.Link] {
+			continue
+		}
+		seen[event.Link] = true
+		sb.WriteString(event.Link)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// tsvFieldCleaner replaces the characters that would break a TSV row
+var tsvFieldCleaner = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// FormatAsTSV formats the scraping results as tab-separated values with a
+// header row, for importing into spreadsheets. Unlike CSV, commas in names
+// need no quoting; tabs and line breaks within fields become spaces.
+func (r *ScrapingResult) FormatAsTSV() (string, error) {
+	var sb strings.Builder
+	sb.WriteString("datetime\tevent\tlink\tsource\thome_team\taway_team\tprice\tprice_value\tcurrency\n")
+
+	for _, event := range r.Events {
+		fields := []string{
+			event.DateTime,
+			event.Event,
+			event.Link,
+			event.Source,
+			event.HomeTeam,
+			event.AwayTeam,
+			event.Price,
+			priceString(event.PriceValue),
+			event.Currency,
+		}
+		for i, field := range fields {
+			fields[i] = tsvFieldCleaner.Replace(field)
+		}
+		sb.WriteString(strings.Join(fields, "\t"))
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
+}
+
+// htmlTemplate renders the scraping results as a standalone HTML page
+var htmlTemplate = template.Must(template.New("events").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Real Madrid tickets</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+td, th { padding: 4px 8px; border-bottom: 1px solid #ddd; text-align: left; }
+img.thumb { width: 64px; height: 64px; object-fit: cover; }
+</style>
+</head>
+<body>
+<h1>Real Madrid tickets</h1>
+<p>{{.Total}} events from {{.Source}}, scraped at {{.Timestamp.Format "2006-01-02 15:04:05"}}</p>
+<table>
+<tr><th></th><th>Date</th><th>Event</th><th>Source</th></tr>
+{{range .Events}}<tr>
+<td>{{if .ImageURL}}<img class="thumb" src="{{.ImageURL}}" alt="">{{end}}</td>
+<td>{{.DateTime}}</td>
+<td><a href="{{.Link}}">{{.Event}}</a></td>
+<td>{{.Source}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// FormatAsHTML formats the scraping results as an HTML page
+func (r *ScrapingResult) FormatAsHTML() (string, error) {
+	// The template reads Source directly, so display names go into a copy
+	page := r
+	if r.displayNames != nil {
+		events := make([]TicketEvent, len(r.Events))
+		for i, event := range r.Events {
+			event.Source = r.sourceLabel(event.Source)
+			events[i] = event
+		}
+		page = r.withEvents(events)
+		page.Source = r.sourceLabel(r.Source)
+	}
+
+	var sb strings.Builder
+	if err := htmlTemplate.Execute(&sb, page); err != nil {
+		return "", fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// rssFeed is the root element of an RSS 2.0 document
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// rssChannel describes the feed and holds its items
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+// rssItem is a single event in the feed
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	PubDate     string  `xml:"pubDate"`
+	GUID        rssGUID `xml:"guid"`
+}
+
+// rssGUID identifies an item; event links are stable so they double as permalinks
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// FormatAsRSS formats the scraping results as an RSS 2.0 feed
+func (r *ScrapingResult) FormatAsRSS() (string, error) {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         fmt.Sprintf("Real Madrid tickets (%s)", r.Source),
+			Link:          r.SourceURL,
+			Description:   "Real Madrid ticket listings",
+			LastBuildDate: r.Timestamp.Format(time.RFC1123Z),
+			Items:         make([]rssItem, 0, len(r.Events)),
+		},
+	}
+
+	for _, event := range r.Events {
+		// Events without a parseable date fall back to the scrape time
+		pubDate := r.Timestamp
+		if eventDate, err := parseEventDate(event.DateTime); err == nil {
+			pubDate = eventDate
 		}
-	case "table", "txt":
-		content = r.FormatAsTable()
-	default:
-		return fmt.Errorf("unsupported format: %s (supported: json, table, txt)", format)
+
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       event.Event,
+			Link:        event.Link,
+			Description: fmt.Sprintf("%s - %s (%s)", event.DateTime, event.Event, event.Source),
+			PubDate:     pubDate.Format(time.RFC1123Z),
+			GUID:        rssGUID{Value: event.Link, IsPermaLink: true},
+		})
+	}
+
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal RSS: %w", err)
 	}
 
-	return os.WriteFile(filename, []byte(content), 0644)
+	return xml.Header + string(data), nil
 }
 
-// FilterByKeyword filters events by keyword in event name
+// SaveToFile saves the results to a file in the specified format
+func (r *ScrapingResult) SaveToFile(filename, format string) error {
+	return FileSink{Path: filename}.Write(r, format)
+}
+
+// FilterByKeyword filters events by keyword in event name, ignoring case and
+// accents so "atletico" matches "Atlético"
 func (r *ScrapingResult) FilterByKeyword(keyword string) *ScrapingResult {
 	if keyword == "" {
 		return r
 	}
 
-	filtered := &ScrapingResult{
-		Events:    []TicketEvent{},
-		Timestamp: r.Timestamp,
-		SourceURL: r.SourceURL,
-		Source:    r.Source,
-	}
+	filtered := r.withEvents([]TicketEvent{})
 
-	keywordLower := strings.ToLower(keyword)
+	fold := func(s string) string { return strings.ToLower(foldAccents(s)) }
+	keywordFolded := fold(keyword)
 	for _, event := range r.Events {
-		if strings.Contains(strings.ToLower(event.Event), keywordLower) ||
-			strings.Contains(strings.ToLower(event.DateTime), keywordLower) ||
-			strings.Contains(strings.ToLower(event.Source), keywordLower) {
+		if strings.Contains(fold(event.Event), keywordFolded) ||
+			strings.Contains(fold(event.DateTime), keywordFolded) ||
+			strings.Contains(fold(event.Source), keywordFolded) {
 			filtered.Events = append(filtered.Events, event)
 		}
 	}
@@ -100,23 +287,37 @@ func (r *ScrapingResult) FilterByKeyword(keyword string) *ScrapingResult {
 	return filtered
 }
 
-// FilterByDate filters events by date range
+// FilterByDate filters events by date range, keeping events whose date can't be parsed
 func (r *ScrapingResult) FilterByDate(startDate, endDate time.Time) *ScrapingResult {
-	filtered := &ScrapingResult{
-		Events:    []TicketEvent{},
-		Timestamp: r.Timestamp,
-		SourceURL: r.SourceURL,
-		Source:    r.Source,
-	}
+	return r.FilterByDateRange(startDate, endDate, true)
+}
+
+// FilterByDateRange filters events by date range; includeUnparseable decides
+// whether events without a parseable date are kept or dropped. Multi-day
+// events are kept when any of their days falls in the range. Event dates are
+// read as local times in startDate's location.
+func (r *ScrapingResult) FilterByDateRange(startDate, endDate time.Time, includeUnparseable bool) *ScrapingResult {
+	filtered := r.withEvents([]TicketEvent{})
+	loc := startDate.Location()
 
 	for _, event := range r.Events {
+		if start, end, ok := event.dateSpan(loc); ok {
+			if !start.After(endDate) && !end.Before(startDate) {
+				filtered.Events = append(filtered.Events, event)
+			}
+			continue
+		}
+
 		// Parse the datetime string to extract date
 		eventDate, err := parseEventDate(event.DateTime)
 		if err != nil {
-			// If we can't parse the date, include the event
-			filtered.Events = append(filtered.Events, event)
+			if includeUnparseable {
+				filtered.Events = append(filtered.Events, event)
+			}
 			continue
 		}
+		eventDate = time.Date(eventDate.Year(), eventDate.Month(), eventDate.Day(),
+			eventDate.Hour(), eventDate.Minute(), eventDate.Second(), 0, loc)
 
 		// Check if event date is within range
 		if (eventDate.After(startDate) || eventDate.Equal(startDate)) &&
@@ -129,7 +330,20 @@ func (r *ScrapingResult) FilterByDate(startDate, endDate time.Time) *ScrapingRes
 	return filtered
 }
 
-// parseEventDate attempts to parse various date formats from event datetime strings
+// FilterWithinDays keeps events dated from today through n days ahead. Unlike
+// FilterByDate, events whose date can't be parsed are dropped, since they can't
+// be shown to fall inside the window.
+func (r *ScrapingResult) FilterWithinDays(n int) *ScrapingResult {
+	// Event dates are parsed without a zone, so the window is in the same naive UTC terms
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	end := today.AddDate(0, 0, n+1).Add(-time.Nanosecond)
+	return r.FilterByDateRange(today, end, false)
+}
+
+// parseEventDate attempts to parse various date formats from event datetime strings.
+// On failure it returns the zero time along with the error, so callers must
+// check the error rather than use the returned time.
 func parseEventDate(dateTimeStr string) (time.Time, error) {
 	// Common date formats to try
 	formats := []string{
@@ -160,21 +374,44 @@ func parseEventDate(dateTimeStr string) (time.Time, error) {
 		}
 	}
 
-	// If no format matches, return current time
-	return time.Now(), fmt.Errorf("unable to parse date: %s", dateTimeStr)
+	// If no format matches, return the zero time
+	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateTimeStr)
 }
 
 // GetSummary returns a summary of the scraping results
 func (r *ScrapingResult) GetSummary() string {
+	// No listings is a valid outcome, e.g. a team with nothing on sale
 	if len(r.Events) == 0 {
-		return "No events found"
+		if r.SourceURL == "" {
+			return "No events found"
+		}
+		at := r.SourceURL
+		if len(r.SourceURLs) > 0 {
+			at = strings.Join(slices.Sorted(maps.Values(r.SourceURLs)), ", ")
+		}
+		summary := fmt.Sprintf("No events found at %s (scraped at %s)", at, r.Timestamp.Format("2006-01-02 15:04:05"))
+		if r.SkippedCount > 0 {
+			summary += fmt.Sprintf("; %d listings skipped (%s)", r.SkippedCount, formatSkipReasons(r.SkipReasons))
+		}
+		return summary
 	}
 
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "Scraping Summary:\n")
 	fmt.Fprintf(&sb, "================\n")
-	fmt.Fprintf(&sb, "Total Events: %d\n", r.Total)
+	fmt.Fprintf(&sb, "Total Events: %d\n", len(r.Events))
+	if r.DuplicatesRemoved > 0 {
+		fmt.Fprintf(&sb, "Raw Events: %d (%d duplicates removed)\n", r.RawTotal, r.DuplicatesRemoved)
+	}
+	if r.SkippedCount > 0 {
+		fmt.Fprintf(&sb, "Skipped Listings: %d (%s)\n", r.SkippedCount, formatSkipReasons(r.SkipReasons))
+	}
 	fmt.Fprintf(&sb, "Source URL: %s\n", r.SourceURL)
+	if len(r.SourceURLs) > 0 {
+		for _, source := range slices.Sorted(maps.Keys(r.SourceURLs)) {
+			fmt.Fprintf(&sb, "  %s: %s\n", r.sourceLabel(source), r.SourceURLs[source])
+		}
+	}
 	fmt.Fprintf(&sb, "Scraped At: %s\n", r.Timestamp.Format("2006-01-02 15:04:05"))
 	fmt.Fprintf(&sb, "\n")
 
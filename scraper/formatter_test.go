@@ -0,0 +1,126 @@
+package scraper
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatAsLinks(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{
+		{Link: "https://example.com/b"},
+		{Link: "https://example.com/a"},
+		{Link: ""},
+		{Link: "https://example.com/b"},
+		{Link: "https://example.com/c"},
+	}}
+
+	want := "https://example.com/b\nhttps://example.com/a\nhttps://example.com/c\n"
+	if got := result.FormatAsLinks(); got != want {
+		t.Errorf("FormatAsLinks() = %q, want %q", got, want)
+	}
+
+	if got := (&ScrapingResult{}).FormatAsLinks(); got != "" {
+		t.Errorf("FormatAsLinks() of no events = %q, want empty", got)
+	}
+}
+
+func TestFilterByKeywordIgnoresAccents(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{
+		{Event: "Atlético de Madrid vs. Real Madrid CF"},
+		{Event: "Real Madrid vs Getafe"},
+		{Event: "ATLETICO MADRID VS SEVILLA"},
+	}}
+
+	for _, keyword := range []string{"atletico", "Atlético", "ATLÉTICO"} {
+		filtered := result.FilterByKeyword(keyword)
+		if filtered.Total != 2 || filtered.Events[0].Event != result.Events[0].Event || filtered.Events[1].Event != result.Events[2].Event {
+			t.Errorf("FilterByKeyword(%q) kept %+v, want both Atlético matches", keyword, filtered.Events)
+		}
+	}
+
+	// Still a substring match
+	if filtered := result.FilterByKeyword("tico de"); filtered.Total != 1 {
+		t.Errorf("FilterByKeyword(%q) kept %d events, want 1", "tico de", filtered.Total)
+	}
+}
+
+func TestFormatAsTSVKeepsCommas(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{
+		{DateTime: "Sat, Sep 2 9:00pm", Event: "Real Madrid vs Getafe, LaLiga", Link: "https://example.com/1", Source: "vividseats"},
+		{Event: "Real Madrid\tvs\nAlaves"},
+	}}
+
+	got, err := result.FormatAsTSV()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want a header and 2 rows:\n%s", len(lines), got)
+	}
+	if lines[0] != "datetime\tevent\tlink\tsource\thome_team\taway_team\tprice\tprice_value\tcurrency" {
+		t.Errorf("header = %q", lines[0])
+	}
+
+	fields := strings.Split(lines[1], "\t")
+	if len(fields) != 9 || fields[0] != "Sat, Sep 2 9:00pm" || fields[1] != "Real Madrid vs Getafe, LaLiga" {
+		t.Errorf("row = %q, want the commas kept unquoted", fields)
+	}
+	if fields := strings.Split(lines[2], "\t"); len(fields) != 9 || fields[1] != "Real Madrid vs Alaves" {
+		t.Errorf("row = %q, want the tab and line break replaced by spaces", fields)
+	}
+}
+
+func TestFilterByDateRangeUnparseableDates(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{
+		{Event: "in range", DateTime: "27 Sep 2025"},
+		{Event: "out of range", DateTime: "27 Dec 2025"},
+		{Event: "garbage", DateTime: "Date TBC"},
+		{Event: "empty"},
+		{Event: "in range ISO", DateTime: "2025-09-30"},
+	}}
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	lenient := result.FilterByDateRange(from, to, true)
+	if want := []string{"in range", "garbage", "empty", "in range ISO"}; !slices.Equal(eventNames(lenient), want) {
+		t.Errorf("lenient = %q, want %q", eventNames(lenient), want)
+	}
+
+	strict := result.FilterByDateRange(from, to, false)
+	if want := []string{"in range", "in range ISO"}; !slices.Equal(eventNames(strict), want) {
+		t.Errorf("strict = %q, want %q", eventNames(strict), want)
+	}
+}
+
+func TestFormatAsTablePriceColumn(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{
+		{DateTime: "27 Sep", Event: "Real Madrid vs Getafe", Price: "€85", Link: "https://example.com/1", Source: "hellotickets"},
+		{DateTime: "28 Sep", Event: "Sevilla vs Betis", Link: "https://example.com/2", Source: "sport365"},
+	}}
+
+	lines := strings.Split(result.FormatAsTable(), "\n")
+	if header := strings.Fields(lines[0]); !slices.Equal(header, []string{"DATETIME", "EVENT", "PRICE", "LINK", "SOURCE"}) {
+		t.Errorf("header = %q, want a PRICE column after EVENT", header)
+	}
+	priceAt, linkAt := strings.Index(lines[0], "PRICE"), strings.Index(lines[0], "LINK")
+	if !strings.HasPrefix(lines[2][priceAt:], "€85 ") {
+		t.Errorf("row %q doesn't show the price under PRICE", lines[2])
+	}
+	if strings.TrimSpace(lines[3][priceAt:linkAt]) != "" {
+		t.Errorf("row %q of an unpriced event doesn't leave PRICE blank", lines[3])
+	}
+}
+
+func TestPriceAlwaysInJSON(t *testing.T) {
+	data, err := json.Marshal(TicketEvent{Event: "Sevilla vs Betis", Source: "sport365"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"price":""`) {
+		t.Errorf("JSON %s, want an empty price rather than none", data)
+	}
+}
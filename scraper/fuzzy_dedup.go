@@ -0,0 +1,80 @@
+package scraper
+
+import "time"
+
+// fuzzyKey is what fuzzy deduplication compares: the team slugs and the day
+type fuzzyKey struct {
+	home, away string
+	day        time.Time
+}
+
+// eventFuzzyKey returns the fuzzy key of an event, or false when its teams or date can't be read
+func eventFuzzyKey(event TicketEvent) (fuzzyKey, bool) {
+	home, away := event.HomeTeam, event.AwayTeam
+	if home == "" || away == "" {
+		var ok bool
+		if home, away, ok = splitTeams(event.Event); !ok {
+			return fuzzyKey{}, false
+		}
+	}
+
+	date, err := parseEventDate(event.DateTime)
+	if err != nil {
+		return fuzzyKey{}, false
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return fuzzyKey{home: TeamSlug(home), away: TeamSlug(away), day: day}, true
+}
+
+// teamsSimilarity scores how alike the teams of two keys are: the weaker of
+// the two team scores, pairing the teams whichever way round fits best
+func teamsSimilarity(a, b fuzzyKey) float64 {
+	straight := min(similarity(a.home, b.home), similarity(a.away, b.away))
+	swapped := min(similarity(a.home, b.away), similarity(a.away, b.home))
+	return max(straight, swapped)
+}
+
+// DeduplicateFuzzy is Deduplicate for names that don't quite agree across
+// sources: two events are the same match when both team names score at least
+// threshold and their dates are at most dayTolerance days apart. Events whose
+// teams or date can't be read are only collapsed on an exact match.
+func (r *ScrapingResult) DeduplicateFuzzy(threshold float64, dayTolerance int) *ScrapingResult {
+	deduped := r.withEvents([]TicketEvent{})
+	index := make(map[string]int)
+	var kept []fuzzyKey // Keys of the kept events, with their position in deduped.Events
+	var keptAt []int
+
+	tolerance := time.Duration(dayTolerance) * 24 * time.Hour
+	for _, event := range r.Events {
+		if key, ok := eventFuzzyKey(event); ok {
+			found := -1
+			for j, other := range kept {
+				if key.day.Sub(other.day).Abs() <= tolerance && teamsSimilarity(key, other) >= threshold {
+					found = keptAt[j]
+					break
+				}
+			}
+			if found >= 0 {
+				deduped.Events[found].Source = mergeSourceNames(deduped.Events[found].Source, event.Source)
+				continue
+			}
+			kept = append(kept, key)
+			keptAt = append(keptAt, len(deduped.Events))
+			deduped.Events = append(deduped.Events, event)
+			continue
+		}
+
+		exact := eventKey(event)
+		if i, exists := index[exact]; exists {
+			deduped.Events[i].Source = mergeSourceNames(deduped.Events[i].Source, event.Source)
+			continue
+		}
+		index[exact] = len(deduped.Events)
+		deduped.Events = append(deduped.Events, event)
+	}
+
+	deduped.Total = len(deduped.Events)
+	deduped.recordDedup(r)
+	return deduped
+}
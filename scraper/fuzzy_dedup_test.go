@@ -0,0 +1,42 @@
+package scraper
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDeduplicateFuzzyDivergentNames(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{
+		{Event: "Atlético de Madrid vs Real Madrid", DateTime: "27 Sep 2025", Source: "hellotickets"},
+		{Event: "Atletico Madrid vs Real Madrid", DateTime: "27 Sep 2025", Source: "vividseats"},
+		{Event: "Real Madrid vs Atletico Madrid", DateTime: "2025-09-28", Source: "sport365"},
+		{Event: "Real Madrid vs Getafe", DateTime: "27 Sep 2025", Source: "vividseats"},
+		{Event: "Bernabéu Stadium Tour", DateTime: "TBD", Source: "hellotickets"},
+		{Event: "Bernabéu Stadium Tour", DateTime: "TBD", Source: "vividseats"},
+	}}
+	result.Total = len(result.Events)
+
+	// Same day only: the listing a day later stays apart
+	deduped := result.DeduplicateFuzzy(0.8, 0)
+	want := []string{"Atlético de Madrid vs Real Madrid", "Real Madrid vs Atletico Madrid", "Real Madrid vs Getafe", "Bernabéu Stadium Tour"}
+	if !slices.Equal(eventNames(deduped), want) {
+		t.Fatalf("DeduplicateFuzzy(0.8, 0) = %q, want %q", eventNames(deduped), want)
+	}
+	if deduped.Events[0].Source != "hellotickets,vividseats" {
+		t.Errorf("merged source = %q, want hellotickets,vividseats", deduped.Events[0].Source)
+	}
+	if deduped.Events[3].Source != "hellotickets,vividseats" {
+		t.Errorf("undated duplicates merged to %q, want an exact match across both", deduped.Events[3].Source)
+	}
+
+	// A day's tolerance also takes in the reversed listing a day later
+	deduped = result.DeduplicateFuzzy(0.8, 1)
+	if deduped.Total != 3 || deduped.Events[0].Source != "hellotickets,vividseats,sport365" {
+		t.Errorf("DeduplicateFuzzy(0.8, 1) = %+v", deduped.Events)
+	}
+
+	// Exact names are needed with a threshold of 1
+	if deduped := result.DeduplicateFuzzy(1, 0); deduped.Total != 5 {
+		t.Errorf("DeduplicateFuzzy(1, 0) kept %d events, want 5", deduped.Total)
+	}
+}
@@ -0,0 +1,209 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/gocolly/colly/v2"
+)
+
+// FieldMap says which CSS selector, relative to a listing, feeds each
+// TicketEvent field when scraping an arbitrary site
+type FieldMap struct {
+	DateTime string `json:"datetime,omitempty"`
+	Event    string `json:"event,omitempty"`
+	HomeTeam string `json:"home_team,omitempty"`
+	AwayTeam string `json:"away_team,omitempty"`
+	Price    string `json:"price,omitempty"`
+	Link     string `json:"link,omitempty"` // Element whose href is the link; empty uses the listing's own href
+}
+
+// Validate checks that the map can produce event names and that every selector is valid CSS
+func (f FieldMap) Validate() error {
+	if f.Event == "" && (f.HomeTeam == "" || f.AwayTeam == "") {
+		return fmt.Errorf("field map needs an event selector or both home_team and away_team selectors")
+	}
+
+	fields := map[string]string{
+		"datetime":  f.DateTime,
+		"event":     f.Event,
+		"home_team": f.HomeTeam,
+		"away_team": f.AwayTeam,
+		"price":     f.Price,
+		"link":      f.Link,
+	}
+	for name, selector := range fields {
+		if selector == "" {
+			continue
+		}
+		if _, err := cascadia.ParseGroup(selector); err != nil {
+			return fmt.Errorf("invalid %s selector %q: %w", name, selector, err)
+		}
+	}
+
+	return nil
+}
+
+// GenericScraper scrapes listings from any page given a listing selector and a
+// field map. It only fetches pages on public addresses, so callers can't use it
+// to reach the server's own network.
+type GenericScraper struct {
+	collector     *colly.Collector
+	listing       string
+	fields        FieldMap
+	skipWarnRatio float64
+
+	checkHost func(ctx context.Context, host string) error // Rejects hosts on non-public addresses; replaced in tests
+}
+
+// NewGenericScraper creates a scraper for an arbitrary site, validating its selectors
+func NewGenericScraper(listing string, fields FieldMap, opts ScraperOptions) (*GenericScraper, error) {
+	if listing == "" {
+		return nil, fmt.Errorf("listing selector is required")
+	}
+	if _, err := cascadia.ParseGroup(listing); err != nil {
+		return nil, fmt.Errorf("invalid listing selector %q: %w", listing, err)
+	}
+	if err := fields.Validate(); err != nil {
+		return nil, err
+	}
+
+	c := colly.NewCollector(
+		colly.UserAgent(opts.userAgent()),
+	)
+
+	// Set up rate limiting to be respectful
+	c.Limit(&colly.LimitRule{
+		DomainGlob:  "*",
+		Parallelism: 1,
+		Delay:       opts.RequestDelay,
+	})
+	c.SetRequestTimeout(opts.Timeout)
+	c.WithTransport(publicOnlyTransport())
+
+	s := &GenericScraper{
+		collector:     c,
+		listing:       listing,
+		fields:        fields,
+		skipWarnRatio: opts.SkipWarnRatio,
+		checkHost:     checkPublicHost,
+	}
+
+	// Redirects get the same host check as the page itself
+	follow := redirectHandler(opts.maxRedirects(), opts.UnexpectedRedirects)
+	c.SetRedirectHandler(func(req *http.Request, via []*http.Request) error {
+		if err := s.checkHost(req.Context(), req.URL.Hostname()); err != nil {
+			return err
+		}
+		return follow(req, via)
+	})
+
+	// Every outbound request draws from the global request budget and carries the configured language
+	c.OnRequest(func(r *colly.Request) {
+		if err := waitLimiter(c.Context, opts.Limiter); err != nil {
+			r.Abort()
+			return
+		}
+		if opts.Language != "" {
+			r.Headers.Set("Accept-Language", opts.Language)
+		}
+	})
+
+	return s, nil
+}
+
+// ScrapeContext scrapes the page at pageURL, aborting when ctx is done. Events
+// are attributed to the page's host. A URL whose host isn't on a public address
+// fails with ErrNonPublicAddress.
+func (s *GenericScraper) ScrapeContext(ctx context.Context, pageURL string) (*ScrapingResult, error) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q: must be an absolute http(s) URL", pageURL)
+	}
+	if err := s.checkHost(ctx, parsed.Hostname()); err != nil {
+		return nil, err
+	}
+	source := parsed.Hostname()
+	baseURL := parsed.Scheme + "://" + parsed.Host
+
+	result := &ScrapingResult{
+		Events:    []TicketEvent{},
+		Timestamp: time.Now(),
+		SourceURL: pageURL,
+		Source:    source,
+	}
+
+	s.collector.OnHTML(s.listing, func(e *colly.HTMLElement) {
+		event, skipReason := s.parseEvent(e, baseURL, source)
+		if event == nil {
+			result.recordSkip(skipReason)
+			return
+		}
+		event.OriginalIndex = len(result.Events)
+		result.Events = append(result.Events, *event)
+	})
+
+	s.collector.OnError(func(r *colly.Response, err error) {
+		log.Printf("Error scraping %s: %v", r.Request.URL, err)
+	})
+
+	s.collector.Context = ctx
+	if err := s.collector.Visit(pageURL); err != nil {
+		return nil, newFetchError(source, pageURL, fmt.Errorf("failed to visit URL: %w", err))
+	}
+
+	warnOnSkips(source, result, s.skipWarnRatio)
+	result.Total = len(result.Events)
+	return result, nil
+}
+
+// parseEvent builds a TicketEvent from a listing element using the field map,
+// returning a nil event and the reason when the element has to be skipped
+func (s *GenericScraper) parseEvent(e *colly.HTMLElement, baseURL, source string) (*TicketEvent, string) {
+	text := func(selector string) string {
+		if selector == "" {
+			return ""
+		}
+		return cleanText(e.ChildText(selector))
+	}
+
+	// Extract link, from the mapped element or the listing itself
+	link := e.Attr("href")
+	if s.fields.Link != "" {
+		link = e.ChildAttr(s.fields.Link, "href")
+	}
+	link = resolveURL(baseURL, link)
+
+	homeTeam := text(s.fields.HomeTeam)
+	awayTeam := text(s.fields.AwayTeam)
+
+	// Use the event selector, or build the name from the teams
+	eventName := text(s.fields.Event)
+	if eventName == "" && homeTeam != "" && awayTeam != "" {
+		eventName = fmt.Sprintf("%s vs. %s", homeTeam, awayTeam)
+	}
+	if eventName == "" {
+		return nil, SkipReasonNoName
+	}
+
+	priceText := text(s.fields.Price)
+	priceValue, currency, _ := parsePrice(priceText)
+
+	return &TicketEvent{
+		DateTime:   text(s.fields.DateTime),
+		Event:      eventName,
+		Link:       link,
+		Source:     source,
+		HomeTeam:   homeTeam,
+		AwayTeam:   awayTeam,
+		Price:      priceText,
+		PriceValue: Price(priceValue),
+		Currency:   currency,
+		ScrapedAt:  time.Now(),
+	}, ""
+}
@@ -0,0 +1,139 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// customLayout is a fixtures page laid out unlike any built-in source
+const customLayout = `<html><body><ul>
+<li class="fixture">
+  <span class="kickoff">27 Sep 2025 4:15pm</span>
+  <span class="home">Real Madrid</span> <span class="away">Getafe</span>
+  <span class="from-price">From €95</span>
+  <a class="tickets" href="/tickets/27-sep">Tickets</a>
+</li>
+<li class="fixture"><span class="kickoff">TBD</span></li>
+</ul></body></html>`
+
+// allowAnyHost lets s scrape the loopback test servers
+func allowAnyHost(s *GenericScraper) {
+	s.checkHost = func(context.Context, string) error { return nil }
+	s.collector.WithTransport(http.DefaultTransport)
+}
+
+func TestGenericScraperFieldMap(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(customLayout))
+	}))
+	defer srv.Close()
+
+	fields := FieldMap{DateTime: ".kickoff", HomeTeam: ".home", AwayTeam: ".away", Price: ".from-price", Link: "a.tickets"}
+	s, err := NewGenericScraper("li.fixture", fields, testOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowAnyHost(s)
+
+	result, err := s.ScrapeContext(context.Background(), srv.URL+"/fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 1 || result.SkippedCount != 1 {
+		t.Fatalf("got %d events and %d skipped, want 1 and 1", result.Total, result.SkippedCount)
+	}
+
+	event := result.Events[0]
+	want := TicketEvent{
+		DateTime:   "27 Sep 2025 4:15pm",
+		Event:      "Real Madrid vs. Getafe",
+		HomeTeam:   "Real Madrid",
+		AwayTeam:   "Getafe",
+		Link:       srv.URL + "/tickets/27-sep",
+		Price:      "From €95",
+		PriceValue: 95,
+		Currency:   "EUR",
+		Source:     "127.0.0.1",
+	}
+	event.ScrapedAt = want.ScrapedAt
+	if !sameDetails(event, want) {
+		t.Errorf("got %+v\nwant %+v", event, want)
+	}
+}
+
+func TestFieldMapValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields FieldMap
+		ok     bool
+	}{
+		{"event", FieldMap{Event: ".name"}, true},
+		{"teams", FieldMap{HomeTeam: ".home", AwayTeam: ".away"}, true},
+		{"home only", FieldMap{HomeTeam: ".home"}, false},
+		{"none", FieldMap{DateTime: ".kickoff"}, false},
+		{"bad selector", FieldMap{Event: "li[", Price: ".price"}, false},
+	}
+	for _, tc := range tests {
+		if err := tc.fields.Validate(); (err == nil) != tc.ok {
+			t.Errorf("%s: Validate() = %v, want ok=%v", tc.name, err, tc.ok)
+		}
+	}
+}
+
+func TestGenericScraperRejectsNonPublicHosts(t *testing.T) {
+	s, err := NewGenericScraper("li", FieldMap{Event: ".name"}, testOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pageURL := range []string{
+		"http://127.0.0.1:8080/",
+		"http://localhost/",
+		"http://10.0.0.5/admin",
+		"http://192.168.1.1/",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[::1]/",
+		"http://100.64.0.1/",
+	} {
+		if _, err := s.ScrapeContext(context.Background(), pageURL); !errors.Is(err, ErrNonPublicAddress) {
+			t.Errorf("ScrapeContext(%q) = %v, want ErrNonPublicAddress", pageURL, err)
+		}
+	}
+}
+
+func TestGenericScraperRejectsRedirectToNonPublicHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	s, err := NewGenericScraper("li", FieldMap{Event: ".name"}, testOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Let the first request through to the test server, but check redirects as usual
+	s.checkHost = func(ctx context.Context, host string) error {
+		if host == "127.0.0.1" {
+			return nil
+		}
+		return checkPublicHost(ctx, host)
+	}
+	s.collector.WithTransport(http.DefaultTransport)
+
+	if _, err := s.ScrapeContext(context.Background(), srv.URL); !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("ScrapeContext = %v, want ErrNonPublicAddress", err)
+	}
+}
+
+func TestPublicOnlyTransportRefusesLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: publicOnlyTransport()}
+	if _, err := client.Get(srv.URL); !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("Get(%s) = %v, want ErrNonPublicAddress", srv.URL, err)
+	}
+}
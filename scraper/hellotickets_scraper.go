@@ -1,41 +1,119 @@
 package scraper
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"golang.org/x/time/rate"
 )
 
 // Scraper handles web scraping operations
 type Scraper struct {
-	collector *colly.Collector
-	baseURL   string
+	collector           *colly.Collector
+	baseURL             string
+	selectors           Selectors
+	language            string
+	captureRaw          bool
+	unexpectedRedirects []string
+	retryEmpty          bool
+	warmup              bool
+	skipWarnRatio       float64
+	credentials         Credentials
+	limiter             *rate.Limiter
 }
 
 // NewScraper creates a new scraper instance
 func NewScraper() *Scraper {
+	return NewScraperWithOptions(DefaultScraperOptions())
+}
+
+// NewScraperWithOptions creates a new scraper instance with the given options
+func NewScraperWithOptions(opts ScraperOptions) *Scraper {
+	// Scrapes reuse the collector's clones, which share its visited-URL store
 	c := colly.NewCollector(
-		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"),
+		colly.UserAgent(opts.userAgent()),
+		colly.AllowURLRevisit(),
 	)
 
 	// Set up rate limiting to be respectful
 	c.Limit(&colly.LimitRule{
 		DomainGlob:  "*",
 		Parallelism: 1,
-		Delay:       1 * time.Second,
+		Delay:       opts.RequestDelay,
 	})
+	c.SetRequestTimeout(opts.Timeout)
+	c.SetRedirectHandler(redirectHandler(opts.maxRedirects(), opts.UnexpectedRedirects))
 
-	return &Scraper{
-		collector: c,
-		baseURL:   "https://www.hellotickets.com",
+	s := &Scraper{
+		collector:           c,
+		baseURL:             "https://www.hellotickets.com",
+		selectors:           opts.Selectors.withDefaults(DefaultHelloTicketsSelectors()),
+		language:            opts.Language,
+		captureRaw:          opts.CaptureRaw,
+		retryEmpty:          opts.RetryEmpty,
+		unexpectedRedirects: opts.UnexpectedRedirects,
+		warmup:              opts.Warmup,
+		skipWarnRatio:       opts.SkipWarnRatio,
+		credentials:         opts.Credentials,
+		limiter:             opts.Limiter,
 	}
+	s.onRequest(c)
+
+	return s
+}
+
+// onRequest makes every outbound request of c draw from the global request
+// budget and carry the configured language and any caller credentials
+func (s *Scraper) onRequest(c *colly.Collector) {
+	c.OnRequest(func(r *colly.Request) {
+		if err := waitLimiter(c.Context, s.limiter); err != nil {
+			r.Abort()
+			return
+		}
+		setLanguage(r.Headers, r.URL, "hellotickets", s.language)
+		s.credentials.apply(r.Headers, r.URL, "hellotickets")
+	})
+}
+
+// SetWarmup sets whether the homepage is visited before the listing, for sites
+// that only serve the listing once a first visit has set their cookies
+func (s *Scraper) SetWarmup(warmup bool) {
+	s.warmup = warmup
+}
+
+// SetMaxRedirects sets how many redirects a request follows before the last
+// response is used; 0 stops at the first redirect
+func (s *Scraper) SetMaxRedirects(n int) {
+	s.collector.SetRedirectHandler(redirectHandler(n, s.unexpectedRedirects))
+}
+
+// SetLanguage sets the Accept-Language and locale cookie sent with every request, e.g. "en" or "es"
+func (s *Scraper) SetLanguage(lang string) {
+	s.language = lang
+}
+
+// Name returns the source name, "hellotickets"
+func (s *Scraper) Name() string {
+	return "hellotickets"
+}
+
+// Scrape scrapes the source's listing page, aborting when ctx is done
+func (s *Scraper) Scrape(ctx context.Context) (*ScrapingResult, error) {
+	return s.ScrapeRealMadridTicketsContext(ctx)
 }
 
 // ScrapeRealMadridTickets scrapes the Real Madrid tickets page
 func (s *Scraper) ScrapeRealMadridTickets() (*ScrapingResult, error) {
+	return s.ScrapeRealMadridTicketsContext(context.Background())
+}
+
+// ScrapeRealMadridTicketsContext scrapes the Real Madrid tickets page, aborting when ctx is done
+func (s *Scraper) ScrapeRealMadridTicketsContext(ctx context.Context) (*ScrapingResult, error) {
 	url := "https://www.hellotickets.com/real-madrid-cf-tickets/p-598?qs=real%20mar"
 
 	result := &ScrapingResult{
@@ -45,54 +123,184 @@ func (s *Scraper) ScrapeRealMadridTickets() (*ScrapingResult, error) {
 		Source:    "hellotickets",
 	}
 
-	s.collector.OnHTML("li.performance.performances-list__item", func(e *colly.HTMLElement) {
-		event := s.parseTicketEvent(e)
-		if event != nil {
-			result.Events = append(result.Events, *event)
+	// Each scrape gets its own clone, since the callbacks hold per-scrape state;
+	// clones share the connections and cookie jar of the long-lived collector
+	c := s.collector.Clone()
+	s.onRequest(c)
+
+	c.OnHTML(s.selectors.Listing, func(e *colly.HTMLElement) {
+		event, skipReason := s.parseTicketEvent(e)
+		if event == nil {
+			result.recordSkip(skipReason)
+			return
 		}
+		event.OriginalIndex = len(result.Events)
+		result.Events = append(result.Events, *event)
 	})
 
-	s.collector.OnError(func(r *colly.Response, err error) {
+	c.OnError(func(r *colly.Response, err error) {
 		log.Printf("Error scraping %s: %v", r.Request.URL, err)
 	})
 
-	err := s.collector.Visit(url)
+	if s.warmup {
+		warm := s.collector.Clone()
+		s.onRequest(warm)
+		warmUp(ctx, warm, "hellotickets", s.baseURL+"/")
+	}
+
+	c.Context = ctx
+	err := c.Visit(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to visit URL: %w", err)
+		return nil, newFetchError("hellotickets", url, fmt.Errorf("failed to visit URL: %w", err))
+	}
+
+	if len(result.Events) == 0 && s.retryEmpty {
+		retryEmptyScrape(ctx, c, "hellotickets", url, func() int { return len(result.Events) })
 	}
 
+	warnOnSkips("hellotickets", result, s.skipWarnRatio)
 	result.Total = len(result.Events)
 	return result, nil
 }
 
-// parseTicketEvent extracts essential ticket event data from HTML element
-func (s *Scraper) parseTicketEvent(e *colly.HTMLElement) *TicketEvent {
+// ScrapeFromFile runs the HelloTickets parser over a saved HTML page instead of the live site
+func (s *Scraper) ScrapeFromFile(path string) (*ScrapingResult, error) {
+	result := &ScrapingResult{
+		Events:    []TicketEvent{},
+		Timestamp: time.Now(),
+		SourceURL: path,
+		Source:    "hellotickets",
+	}
+
+	err := forEachFixtureElement(path, s.selectors.Listing, func(e *colly.HTMLElement) {
+		event, skipReason := s.parseTicketEvent(e)
+		if event == nil {
+			result.recordSkip(skipReason)
+			return
+		}
+		event.OriginalIndex = len(result.Events)
+		result.Events = append(result.Events, *event)
+	})
+	if err != nil {
+		return nil, newScrapeError("hellotickets", path, ErrorKindParse, err)
+	}
+
+	warnOnSkips("hellotickets", result, s.skipWarnRatio)
+	result.Total = len(result.Events)
+	return result, nil
+}
+
+// parseTicketEvent extracts essential ticket event data from HTML element,
+// returning a nil event and the reason when the element has to be skipped
+func (s *Scraper) parseTicketEvent(e *colly.HTMLElement) (*TicketEvent, string) {
 	// Extract link
-	link := e.ChildAttr("a.performance__link", "href")
+	link := e.ChildAttr(s.selectors.Link, "href")
 	if link == "" {
-		return nil
+		return nil, SkipReasonNoLink
 	}
 
 	// Convert to full URL
-	if !strings.HasPrefix(link, "http") {
-		link = s.baseURL + link
-	}
+	link = resolveURL(s.baseURL, link)
 
 	// Extract date information
-	dateMonth := strings.TrimSpace(e.ChildText(".performance__date-month"))
-	day := strings.TrimSpace(e.ChildText(".performance__date-day p:first-child"))
-	timeStr := strings.TrimSpace(e.ChildText(".performance__date-day p:last-child"))
+	dateMonth := cleanText(e.ChildText(s.selectors.Date))
+	day := cleanText(e.ChildText(s.selectors.Day))
+	timeStr := cleanText(e.ChildText(s.selectors.Time))
 
 	// Extract event name
-	event := strings.TrimSpace(e.ChildText(".performance__description__name"))
+	event := cleanText(e.ChildText(s.selectors.Event))
+
+	// Multi-day events list a range; DateTime keeps its first day
+	dateMonth, startDate, endDate := splitDateRange(dateMonth)
+	if startDate != "" {
+		day = firstOfRange(day)
+	}
+
+	// Listings showing when the doors open label both times; the match starts at kick-off
+	listingText := cleanText(e.Text)
+	doors := labelledTime(doorsPattern, listingText)
+	if kickoff := labelledTime(kickoffPattern, listingText); kickoff != "" {
+		timeStr = kickoff
+	}
+
+	// Listings with a single line in the day block return it for both the day
+	// and time selectors, and that line isn't always a time
+	if timeStr == day || !timeOfDayPattern.MatchString(timeStr) {
+		timeStr = ""
+	}
 
-	// Combine date and time into single string
-	datetime := fmt.Sprintf("%s %s %s", dateMonth, day, timeStr)
+	// Combine the parts that are present into a single string
+	datetime := strings.Join(nonEmpty(dateMonth, day, timeStr), " ")
+
+	// Without a month/day the listing can't be placed on the calendar
+	status := ""
+	if dateMonth == "" {
+		status = EventStatusDateTBD
+	}
+
+	// Extract round when the listing mentions one
+	description := e.ChildText(".performance__description")
+	round := extractRound(description)
+	competition := detectCompetition(description)
+
+	// Extract thumbnail image
+	imageURL := resolveURL(s.baseURL, childImageSrc(e))
+
+	// Extract price when the listing shows one
+	priceText := ""
+	if s.selectors.Price != "" {
+		priceText = strings.TrimSpace(e.ChildText(s.selectors.Price))
+	}
+	priceValue, currency, _ := parsePrice(priceText)
 
 	return &TicketEvent{
-		DateTime: datetime,
-		Event:    event,
-		Link:     link,
-		Source:   "hellotickets",
+		DateTime:    datetime,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Event:       event,
+		Link:        link,
+		Source:      "hellotickets",
+		ImageURL:    imageURL,
+		Price:       priceText,
+		PriceValue:  Price(priceValue),
+		Currency:    currency,
+		Round:       round,
+		Competition: competition,
+		Status:      status,
+		DoorsTime:   doors,
+		Meta:        eventMeta(MetaHelloTicketsPerformanceID, helloTicketsPerformanceID(link)),
+		ScrapedAt:   time.Now(),
+		RawHTML:     rawHTML(e.DOM, s.captureRaw),
+	}, ""
+}
+
+// timeOfDayPattern matches times such as "4:15pm", "9pm" or "21:00"
+var timeOfDayPattern = regexp.MustCompile(`(?i)^\d{1,2}(:\d{2})?\s*(am|pm)$|^\d{1,2}:\d{2}$`)
+
+// kickoffPattern and doorsPattern find the labelled times of listings that show
+// both, e.g. "Doors 3:00pm Kick-off 4:15pm"
+var (
+	kickoffPattern = regexp.MustCompile(`(?i)kick[\s-]?off:?\s*(\d{1,2}(?::\d{2})?\s*(?:am|pm)?)`)
+	doorsPattern   = regexp.MustCompile(`(?i)doors(?:\s+open)?:?\s*(\d{1,2}(?::\d{2})?\s*(?:am|pm)?)`)
+)
+
+// labelledTime returns the time the pattern captures in text, in the listing
+// format "4:15pm", or "" when the label is absent
+func labelledTime(pattern *regexp.Regexp, text string) string {
+	match := pattern.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+	return strings.ToLower(strings.ReplaceAll(match[1], " ", ""))
+}
+
+// nonEmpty returns the given strings with the empty ones removed
+func nonEmpty(parts ...string) []string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
 	}
+	return kept
 }
@@ -0,0 +1,160 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// helloListing renders one HelloTickets listing as the default selectors
+// expect it; dayLines fill the day block, usually the weekday and the time
+func helloListing(link, name, dateMonth string, dayLines ...string) string {
+	var day strings.Builder
+	for _, line := range dayLines {
+		fmt.Fprintf(&day, "<p>%s</p>", line)
+	}
+	return fmt.Sprintf(`<li class="performance performances-list__item">
+<a class="performance__link" href="%s">
+<div class="performance__date"><div class="performance__date-month">%s</div><div class="performance__date-day">%s</div></div>
+<div class="performance__description"><span class="performance__description__name">%s</span></div>
+</a>
+</li>`, link, dateMonth, day.String(), name)
+}
+
+// writePage saves listings as an HTML page for ScrapeFromFile
+func writePage(t *testing.T, listings ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "page.html")
+	page := "<html><body><ul>" + strings.Join(listings, "\n") + "</ul></body></html>"
+	if err := os.WriteFile(path, []byte(page), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestHelloTicketsDateAssembly(t *testing.T) {
+	tests := []struct {
+		name      string
+		listing   string
+		datetime  string
+		status    string
+		startDate string
+		endDate   string
+	}{
+		{
+			name:     "time present",
+			listing:  helloListing("/atletico/2263527/2", "Atlético de Madrid vs. Real Madrid CF", "27 Sep", "Sat", "4:15pm"),
+			datetime: "27 Sep Sat 4:15pm",
+		},
+		{
+			name:     "time absent",
+			listing:  helloListing("/getafe/2263528/2", "Real Madrid CF vs. Getafe", "27 Sep", "Sat"),
+			datetime: "27 Sep Sat",
+		},
+		{
+			name:     "date in the time slot",
+			listing:  helloListing("/alaves/2263529/2", "Real Madrid CF vs. Alavés", "27 Sep", "Sat", "27 Sep"),
+			datetime: "27 Sep Sat",
+		},
+		{
+			name:     "no date",
+			listing:  helloListing("/sociedad/2301155/2", "Real Madrid CF vs. Real Sociedad", ""),
+			datetime: "",
+			status:   EventStatusDateTBD,
+		},
+		{
+			name:      "date range",
+			listing:   helloListing("/cup/2301156/2", "Real Madrid CF Summer Cup", "12 - 14 Jun 2026", "Fri - Sun"),
+			datetime:  "12 Jun 2026 Fri",
+			startDate: "2026-06-12",
+			endDate:   "2026-06-14",
+		},
+	}
+
+	s := NewScraperWithOptions(testOptions())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := s.ScrapeFromFile(writePage(t, tc.listing))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Total != 1 {
+				t.Fatalf("got %d events, want 1", result.Total)
+			}
+			event := result.Events[0]
+			if event.DateTime != tc.datetime {
+				t.Errorf("DateTime = %q, want %q", event.DateTime, tc.datetime)
+			}
+			if event.Status != tc.status {
+				t.Errorf("Status = %q, want %q", event.Status, tc.status)
+			}
+			if event.StartDate != tc.startDate || event.EndDate != tc.endDate {
+				t.Errorf("range = %q to %q, want %q to %q", event.StartDate, event.EndDate, tc.startDate, tc.endDate)
+			}
+		})
+	}
+}
+
+func TestHelloTicketsMetaFromLink(t *testing.T) {
+	s := NewScraperWithOptions(testOptions())
+	result, err := s.ScrapeFromFile(writePage(t,
+		helloListing("/spain/madrid/sports/atletico-madrid-tickets/2025-09-27,1615/2263527/2", "Atlético de Madrid vs. Real Madrid CF", "27 Sep", "Sat", "4:15pm"),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 1 {
+		t.Fatalf("got %d events, want 1", result.Total)
+	}
+	if id := result.Events[0].Meta[MetaHelloTicketsPerformanceID]; id != "2263527" {
+		t.Errorf("performance ID = %q, want the link's 2263527", id)
+	}
+}
+
+func TestConcurrentScrapesStayApart(t *testing.T) {
+	s := NewScraperWithOptions(testOptions())
+
+	// Each request gets its own listing, served slowly so the scrapes overlap
+	var served atomic.Int32
+	mockSite(t, s.collector, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := served.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprintf(w, "<html><body><ul>%s</ul></body></html>",
+			helloListing(fmt.Sprintf("/match/%d/2", n), fmt.Sprintf("Real Madrid CF vs. Team %d", n), "27 Sep", "Sat", "4:15pm"))
+	}))
+
+	const scrapes = 8
+	results := make([]*ScrapingResult, scrapes)
+	errs := make([]error, scrapes)
+	var wg sync.WaitGroup
+	for i := range scrapes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = s.Scrape(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for i, result := range results {
+		if errs[i] != nil {
+			t.Fatalf("scrape %d: %v", i, errs[i])
+		}
+		if result.Total != 1 {
+			t.Fatalf("scrape %d got %d events, want only its own: %+v", i, result.Total, result.Events)
+		}
+		link := result.Events[0].Link
+		if seen[link] {
+			t.Errorf("two scrapes got %s", link)
+		}
+		seen[link] = true
+	}
+}
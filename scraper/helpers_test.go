@@ -0,0 +1,46 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// mockSite serves every request of c from handler, whatever host the scraper
+// asks for, so scrapers keep their real URLs while tests stay offline
+func mockSite(t *testing.T, c *colly.Collector, handler http.Handler) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.WithTransport(rewriteTransport{target: target})
+}
+
+// rewriteTransport sends requests to target, keeping the original Host header
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (rt rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Host = req.URL.Host
+	out.URL.Scheme = rt.target.Scheme
+	out.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(out)
+}
+
+// testOptions returns scraper options without request delays
+func testOptions() ScraperOptions {
+	opts := DefaultScraperOptions()
+	opts.RequestDelay = 0
+	opts.Timeout = 5 * time.Second
+	return opts
+}
@@ -0,0 +1,40 @@
+package scraper
+
+import "maps"
+
+// SourceStatusCapped marks a source whose events were cut by LimitPerSource; the scrape itself succeeded
+const SourceStatusCapped = "capped"
+
+// LimitPerSource keeps the first n events of each source, so one source can't
+// swamp a merged result. Capped sources are reported as "capped" in the source
+// status. Events merged from several sources count as their combined source.
+func (r *ScrapingResult) LimitPerSource(n int) *ScrapingResult {
+	if n <= 0 {
+		return r
+	}
+
+	kept := make(map[string]int)
+	capped := make(map[string]bool)
+	events := []TicketEvent{}
+	for _, event := range r.Events {
+		if kept[event.Source] >= n {
+			capped[event.Source] = true
+			continue
+		}
+		kept[event.Source]++
+		events = append(events, event)
+	}
+
+	limited := r.withEvents(events)
+	if len(capped) > 0 {
+		// The status map is shared with the cached result, so it is copied before marking
+		limited.SourceStatus = maps.Clone(r.SourceStatus)
+		if limited.SourceStatus == nil {
+			limited.SourceStatus = make(map[string]string)
+		}
+		for source := range capped {
+			limited.SourceStatus[source] = SourceStatusCapped
+		}
+	}
+	return limited
+}
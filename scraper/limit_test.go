@@ -0,0 +1,45 @@
+package scraper
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLimitPerSourceUnevenCounts(t *testing.T) {
+	var events []TicketEvent
+	for i := range 12 {
+		events = append(events, TicketEvent{Event: fmt.Sprintf("vivid %d", i), Source: "vividseats"})
+	}
+	events = append(events,
+		TicketEvent{Event: "hello 0", Source: "hellotickets"},
+		TicketEvent{Event: "hello 1", Source: "hellotickets"},
+		TicketEvent{Event: "sport 0", Source: "sport365"},
+	)
+	status := map[string]string{"vividseats": "ok", "hellotickets": "ok", "sport365": "ok"}
+	result := &ScrapingResult{Events: events, Total: len(events), SourceStatus: status}
+
+	limited := result.LimitPerSource(3)
+	if limited.Total != 6 {
+		t.Fatalf("kept %d events, want 3 + 2 + 1", limited.Total)
+	}
+	for i, want := range []string{"vivid 0", "vivid 1", "vivid 2", "hello 0", "hello 1", "sport 0"} {
+		if limited.Events[i].Event != want {
+			t.Errorf("event %d = %q, want %q", i, limited.Events[i].Event, want)
+		}
+	}
+
+	want := map[string]string{"vividseats": SourceStatusCapped, "hellotickets": "ok", "sport365": "ok"}
+	for source, s := range want {
+		if limited.SourceStatus[source] != s {
+			t.Errorf("status of %s = %q, want %q", source, limited.SourceStatus[source], s)
+		}
+	}
+	if status["vividseats"] != "ok" {
+		t.Error("LimitPerSource changed the original status map")
+	}
+
+	// Zero means unlimited
+	if all := result.LimitPerSource(0); all.Total != len(events) {
+		t.Errorf("LimitPerSource(0) kept %d events, want all %d", all.Total, len(events))
+	}
+}
@@ -0,0 +1,31 @@
+package scraper
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// NewRequestLimiter returns the global outbound request budget shared by all
+// scrapers: perMinute requests per minute regardless of which site they go to,
+// with up to burst of them allowed back to back. It returns nil (no limit) when
+// perMinute is not positive.
+func NewRequestLimiter(perMinute, burst int) *rate.Limiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), burst)
+}
+
+// waitLimiter blocks until l allows the next request or ctx is done.
+// A nil limiter never blocks.
+func waitLimiter(ctx context.Context, l *rate.Limiter) error {
+	if l == nil {
+		return nil
+	}
+	return l.Wait(ctx)
+}
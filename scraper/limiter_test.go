@@ -0,0 +1,100 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewRequestLimiterDisabled(t *testing.T) {
+	for _, perMinute := range []int{0, -1} {
+		if l := NewRequestLimiter(perMinute, 1); l != nil {
+			t.Errorf("NewRequestLimiter(%d, 1) = %v, want nil", perMinute, l)
+		}
+	}
+
+	if err := waitLimiter(context.Background(), nil); err != nil {
+		t.Errorf("nil limiter Wait = %v, want nil", err)
+	}
+}
+
+func TestRequestLimiterSpacesRequests(t *testing.T) {
+	l := NewRequestLimiter(6000, 1) // one slot every 10ms
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := waitLimiter(context.Background(), l); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("5 requests took %v, want at least 40ms", elapsed)
+	}
+}
+
+func TestRequestLimiterWaitCancelled(t *testing.T) {
+	l := NewRequestLimiter(1, 1) // one slot a minute
+	if err := waitLimiter(context.Background(), l); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := waitLimiter(ctx, l); err == nil {
+		t.Error("Wait returned nil after ctx was done, want an error")
+	}
+}
+
+func TestRequestLimiterBurst(t *testing.T) {
+	l := NewRequestLimiter(600, 3) // one slot every 100ms, three at once
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := waitLimiter(context.Background(), l); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("3 requests within the burst took %v, want no wait", elapsed)
+	}
+
+	if err := waitLimiter(context.Background(), l); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("4th request came after %v, want it held back about 100ms", elapsed)
+	}
+}
+
+func TestScrapersShareRequestBudget(t *testing.T) {
+	var hits atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte("<html><body></body></html>"))
+	})
+
+	opts := testOptions()
+	opts.Limiter = NewRequestLimiter(600, 1) // one slot every 100ms
+
+	hello := NewScraperWithOptions(opts)
+	mockSite(t, hello.collector, handler)
+	vivid := NewVividSeatsScraperWithOptions(opts)
+	mockSite(t, vivid.collector, handler)
+
+	start := time.Now()
+	for _, s := range []SourceScraper{hello, vivid, hello} {
+		if _, err := s.Scrape(context.Background()); err != nil {
+			t.Fatalf("%s: %v", s.Name(), err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	if got := int(hits.Load()); got < 3 {
+		t.Fatalf("server saw %d requests, want at least 3", got)
+	}
+	if want := time.Duration(hits.Load()-1) * 100 * time.Millisecond; elapsed < want {
+		t.Errorf("%d requests took %v, want at least %v", hits.Load(), elapsed, want)
+	}
+}
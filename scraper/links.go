@@ -0,0 +1,104 @@
+package scraper
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// resolveURL resolves a possibly relative link against the site's base URL
+func resolveURL(baseURL, link string) string {
+	if link == "" {
+		return ""
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return link
+	}
+
+	ref, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	return base.ResolveReference(ref).String()
+}
+
+// childImageSrc returns the source of the first image in a listing element,
+// honouring the lazy-loading attributes sites use instead of src
+func childImageSrc(e *colly.HTMLElement) string {
+	for _, attr := range []string{"src", "data-src", "data-lazy-src"} {
+		src := strings.TrimSpace(e.ChildAttr("img", attr))
+		if src != "" && !strings.HasPrefix(src, "data:") {
+			return src
+		}
+	}
+	return ""
+}
+
+// DefaultTrackingParams lists query parameters that only carry tracking state
+var DefaultTrackingParams = []string{
+	"utm_source",
+	"utm_medium",
+	"utm_campaign",
+	"utm_term",
+	"utm_content",
+	"gclid",
+	"fbclid",
+	"qs",
+	"itemListId",
+	"itemListName",
+	"itemSublist",
+}
+
+// CleanLinks strips the given query parameters from each event's link,
+// falling back to DefaultTrackingParams when none are given
+func (r *ScrapingResult) CleanLinks(params ...string) *ScrapingResult {
+	if len(params) == 0 {
+		params = DefaultTrackingParams
+	}
+
+	cleaned := r.withEvents(make([]TicketEvent, len(r.Events)))
+
+	for i, event := range r.Events {
+		link := cleanLink(event.Link, params)
+		if link != event.Link {
+			if event.OriginalLink == "" {
+				event.OriginalLink = event.Link
+			}
+			event.Link = link
+		}
+		cleaned.Events[i] = event
+	}
+
+	return cleaned
+}
+
+// cleanLink removes the given query parameters (case-insensitive) from a URL
+func cleanLink(link string, params []string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.RawQuery == "" {
+		return link
+	}
+
+	query := u.Query()
+	removed := false
+	for key := range query {
+		for _, param := range params {
+			if strings.EqualFold(key, param) {
+				query.Del(key)
+				removed = true
+				break
+			}
+		}
+	}
+
+	if !removed {
+		return link
+	}
+
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,57 @@
+package scraper
+
+import (
+	"sort"
+	"strings"
+)
+
+// Listing types; anything that isn't a recognised add-on is a match
+const (
+	ListingTypeMatch   = "match"
+	ListingTypeParking = "parking"
+	ListingTypePackage = "package"
+)
+
+// DefaultListingTypeKeywords returns the title keywords that mark a listing as a
+// non-match add-on, keyed by listing type
+func DefaultListingTypeKeywords() map[string][]string {
+	return map[string][]string{
+		ListingTypeParking: {"Parking"},
+		ListingTypePackage: {"Hospitality", "VIP Package", "Package"},
+	}
+}
+
+// classifyListing returns the listing type for a title, matching keywords case-insensitively.
+// Types are checked in sorted order so a title matching several keywords is classified consistently.
+func classifyListing(title string, keywords map[string][]string) string {
+	types := make([]string, 0, len(keywords))
+	for listingType := range keywords {
+		types = append(types, listingType)
+	}
+	sort.Strings(types)
+
+	titleLower := strings.ToLower(title)
+	for _, listingType := range types {
+		for _, keyword := range keywords[listingType] {
+			if keyword != "" && strings.Contains(titleLower, strings.ToLower(keyword)) {
+				return listingType
+			}
+		}
+	}
+	return ListingTypeMatch
+}
+
+// OnlyMatches drops parking passes, hospitality packages and other non-match
+// listings. Events without a type are treated as matches.
+func (r *ScrapingResult) OnlyMatches() *ScrapingResult {
+	filtered := r.withEvents([]TicketEvent{})
+
+	for _, event := range r.Events {
+		if event.Type == "" || event.Type == ListingTypeMatch {
+			filtered.Events = append(filtered.Events, event)
+		}
+	}
+
+	filtered.Total = len(filtered.Events)
+	return filtered
+}
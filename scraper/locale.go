@@ -0,0 +1,82 @@
+package scraper
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// localeCookies names the cookie a source reads its display language from,
+// for sites that don't go by Accept-Language alone
+var localeCookies = map[string]string{
+	"hellotickets": "locale",
+}
+
+// setLanguage sets the Accept-Language of a request to u and, on sources that
+// keep the language in a cookie, that cookie. An empty lang leaves the request as is.
+func setLanguage(headers *http.Header, u *url.URL, source, lang string) {
+	if lang == "" {
+		return
+	}
+	headers.Set("Accept-Language", lang)
+
+	if name, ok := localeCookies[source]; ok && onSourceDomain(u, source) {
+		// The cookie holds a single language, e.g. "es-ES" for "es-ES,es;q=0.9"
+		primary, _, _ := strings.Cut(lang, ",")
+		primary, _, _ = strings.Cut(primary, ";")
+		addCookies(headers, (&http.Cookie{Name: name, Value: strings.TrimSpace(primary)}).String())
+	}
+}
+
+// canonicalByLocale maps a locale to the preferred local form of canonical team
+// names. Canonical names are English, so "en" needs no entries.
+var canonicalByLocale = map[string]map[string]string{
+	"es": {
+		"Athletic Bilbao":   "Athletic Club",
+		"Atlético Madrid":   "Atlético de Madrid",
+		"Barcelona":         "FC Barcelona",
+		"Celta de Vigo":     "RC Celta",
+		"Deportivo Alavés":  "Alavés",
+		"Mallorca":          "RCD Mallorca",
+		"Oviedo":            "Real Oviedo",
+		"Sevilla":           "Sevilla FC",
+		"Valencia":          "Valencia CF",
+		"Villarreal":        "Villarreal CF",
+		"Manchester United": "Manchester United",
+		"Manchester City":   "Manchester City",
+		"Juventus":          "Juventus",
+		"SL Benfica":        "Benfica",
+		"AS Monaco":         "Mónaco",
+		"Olympiacos FC":     "Olympiacos",
+		"Kairat Almaty":     "Kairat Almaty",
+	},
+	"en": {},
+}
+
+// LocalizeNames rewrites canonical team names in each event (event name and
+// home/away teams) to the locale's preferred form. Unknown locales leave names as is.
+func (r *ScrapingResult) LocalizeNames(locale string) *ScrapingResult {
+	names, ok := canonicalByLocale[strings.ToLower(locale)]
+	if !ok || len(names) == 0 {
+		return r
+	}
+
+	localize := func(team string) string {
+		if localized, exists := names[team]; exists {
+			return localized
+		}
+		return team
+	}
+
+	localized := r.withEvents(make([]TicketEvent, len(r.Events)))
+	for i, event := range r.Events {
+		event.HomeTeam = localize(event.HomeTeam)
+		event.AwayTeam = localize(event.AwayTeam)
+		if home, away, ok := splitTeams(event.Event); ok {
+			event.Event = localize(home) + " vs " + localize(away)
+		}
+		localized.Events[i] = event
+	}
+
+	return localized
+}
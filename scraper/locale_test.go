@@ -0,0 +1,62 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// sentLocale is what a mock site received as the request's language
+type sentLocale struct {
+	language string // Accept-Language
+	locale   string // locale cookie
+	session  string // caller-supplied session cookie
+}
+
+// scrapeSentLocale scrapes s against a mock site, reporting the language it sent
+func scrapeSentLocale(t *testing.T, s SourceScraper, c *colly.Collector) sentLocale {
+	t.Helper()
+	var got sentLocale
+	mockSite(t, c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.language = r.Header.Get("Accept-Language")
+		if cookie, err := r.Cookie("locale"); err == nil {
+			got.locale = cookie.Value
+		}
+		if cookie, err := r.Cookie("session"); err == nil {
+			got.session = cookie.Value
+		}
+		w.Write([]byte("<html></html>"))
+	}))
+
+	if _, err := s.Scrape(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestScrapersSendLanguage(t *testing.T) {
+	options := func(lang string) ScraperOptions {
+		opts := testOptions()
+		opts.Language = lang
+		opts.VividSeatsPerformers = []string{"11111"}
+		opts.Credentials = Credentials{Cookies: []Cookie{{Name: "session", Value: "abc"}}}
+		return opts
+	}
+
+	hello := NewScraperWithOptions(options("es-ES,es;q=0.9"))
+	if got := scrapeSentLocale(t, hello, hello.collector); got != (sentLocale{"es-ES,es;q=0.9", "es-ES", "abc"}) {
+		t.Errorf("hellotickets sent %+v", got)
+	}
+
+	vivid := NewVividSeatsScraperWithOptions(options("es"))
+	if got := scrapeSentLocale(t, vivid, vivid.collector); got != (sentLocale{"es", "", "abc"}) {
+		t.Errorf("vividseats sent %+v", got)
+	}
+
+	unset := NewScraperWithOptions(options(""))
+	if got := scrapeSentLocale(t, unset, unset.collector); got != (sentLocale{"", "", "abc"}) {
+		t.Errorf("hellotickets sent %+v without a language", got)
+	}
+}
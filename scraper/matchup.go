@@ -0,0 +1,29 @@
+package scraper
+
+import (
+	"sort"
+	"strings"
+)
+
+// MatchupKey identifies a match across sources, e.g. "barcelona|real-madrid|2025-09-27":
+// the two team slugs in sorted order, so home and away don't matter, and the
+// day. It relies on the teams set by normalization (or by sources listing them
+// separately) and returns "" when the teams or the date can't be read.
+func (e TicketEvent) MatchupKey() string {
+	home, away := e.HomeTeam, e.AwayTeam
+	if home == "" || away == "" {
+		var ok bool
+		if home, away, ok = splitTeams(e.Event); !ok {
+			return ""
+		}
+	}
+
+	date, err := parseEventDate(e.DateTime)
+	if err != nil {
+		return ""
+	}
+
+	teams := []string{TeamSlug(home), TeamSlug(away)}
+	sort.Strings(teams)
+	return strings.Join(append(teams, date.Format("2006-01-02")), "|")
+}
@@ -0,0 +1,31 @@
+package scraper
+
+import "testing"
+
+func TestMatchupKey(t *testing.T) {
+	home := TicketEvent{HomeTeam: "Real Madrid", AwayTeam: "Barcelona", DateTime: "27 Sep 2025"}
+	away := TicketEvent{HomeTeam: "Barcelona", AwayTeam: "Real Madrid", DateTime: "2025-09-27"}
+
+	const want = "barcelona|real-madrid|2025-09-27"
+	if got := home.MatchupKey(); got != want {
+		t.Errorf("MatchupKey() = %q, want %q", got, want)
+	}
+	if got := away.MatchupKey(); got != want {
+		t.Errorf("reversed MatchupKey() = %q, want %q", got, want)
+	}
+
+	// Without separate teams the normalized event name is split
+	fromName := TicketEvent{Event: "Barcelona vs Real Madrid", DateTime: "Sep 27 2025"}
+	if got := fromName.MatchupKey(); got != want {
+		t.Errorf("MatchupKey() from the name = %q, want %q", got, want)
+	}
+
+	for _, event := range []TicketEvent{
+		{Event: "Real Madrid Stadium Tour", DateTime: "27 Sep 2025"},
+		{HomeTeam: "Real Madrid", AwayTeam: "Barcelona", DateTime: "TBD"},
+	} {
+		if got := event.MatchupKey(); got != "" {
+			t.Errorf("MatchupKey() of %+v = %q, want empty", event, got)
+		}
+	}
+}
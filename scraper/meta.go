@@ -0,0 +1,76 @@
+package scraper
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+)
+
+// Keys of the source-specific values kept in TicketEvent.Meta. Each is taken
+// from the event link, so it is only set when the link carries the ID.
+const (
+	// MetaHelloTicketsPerformanceID is the numeric performance ID, e.g. "2263527"
+	MetaHelloTicketsPerformanceID = "hellotickets_performance_id"
+	// MetaVividSeatsProductionID is the numeric production ID, e.g. "5631001"
+	MetaVividSeatsProductionID = "vividseats_production_id"
+	// MetaSport365MatchID is the match ID, e.g. "1-5523107"
+	MetaSport365MatchID = "sport365_match_id"
+)
+
+// helloTicketsPerformancePattern finds the performance ID in a HelloTickets event link,
+// the number before the trailing page number, e.g. ".../2025-09-27,1615/2263527/2"
+var helloTicketsPerformancePattern = regexp.MustCompile(`/(\d+)/\d+/?$`)
+
+// vividSeatsProductionPattern finds the production ID in a VividSeats event link, e.g. ".../production/5631001"
+var vividSeatsProductionPattern = regexp.MustCompile(`/production/(\d+)`)
+
+// sport365MatchPattern matches the last path segment of a Sport365 match link, e.g. "1-5523107"
+var sport365MatchPattern = regexp.MustCompile(`^\d+-\d+$`)
+
+// eventMeta builds a Meta map from key/value pairs, dropping empty values.
+// It returns nil when nothing is left so the field is omitted.
+func eventMeta(pairs ...string) map[string]string {
+	var meta map[string]string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			continue
+		}
+		if meta == nil {
+			meta = make(map[string]string)
+		}
+		meta[pairs[i]] = pairs[i+1]
+	}
+	return meta
+}
+
+// helloTicketsPerformanceID returns the performance ID of a HelloTickets event link, or "" if it has none
+func helloTicketsPerformanceID(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	if m := helloTicketsPerformancePattern.FindStringSubmatch(u.Path); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// vividSeatsProductionID returns the production ID of a VividSeats event link, or "" if it has none
+func vividSeatsProductionID(link string) string {
+	if m := vividSeatsProductionPattern.FindStringSubmatch(link); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// sport365MatchID returns the match ID of a Sport365 match link, or "" if it has none
+func sport365MatchID(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	if id := path.Base(u.Path); sport365MatchPattern.MatchString(id) {
+		return id
+	}
+	return ""
+}
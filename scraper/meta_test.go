@@ -0,0 +1,31 @@
+package scraper
+
+import "testing"
+
+func TestMetaIDsFromLinks(t *testing.T) {
+	for _, tc := range []struct {
+		name, link, want string
+		id               func(string) string
+	}{
+		{"hellotickets", "https://www.hellotickets.com/spain/madrid/sports/atletico-madrid-tickets/2025-09-27,1615/2263527/2", "2263527", helloTicketsPerformanceID},
+		{"hellotickets with query", "https://www.hellotickets.com/spain/madrid/sports/real-madrid-tickets/tbd/2301155/2?lang=en", "2301155", helloTicketsPerformanceID},
+		{"hellotickets without ID", "https://www.hellotickets.com/spain/madrid/sports/real-madrid-tickets", "", helloTicketsPerformanceID},
+		{"vividseats", "https://www.vividseats.com/real-madrid-tickets/production/5631001", "5631001", vividSeatsProductionID},
+		{"vividseats without ID", "https://www.vividseats.com/real-madrid-tickets", "", vividSeatsProductionID},
+		{"sport365", "https://www.sport365.com/football/match/real-madrid-barcelona/1-5523171", "1-5523171", sport365MatchID},
+		{"sport365 without ID", "https://www.sport365.com/football/match/real-madrid-barcelona", "", sport365MatchID},
+	} {
+		if got := tc.id(tc.link); got != tc.want {
+			t.Errorf("%s: ID of %q = %q, want %q", tc.name, tc.link, got, tc.want)
+		}
+	}
+}
+
+func TestEventMetaOmitsEmptyValues(t *testing.T) {
+	if meta := eventMeta(MetaVividSeatsProductionID, ""); meta != nil {
+		t.Errorf("eventMeta with no values = %v, want nil", meta)
+	}
+	if meta := eventMeta(MetaVividSeatsProductionID, "5631001"); meta[MetaVividSeatsProductionID] != "5631001" {
+		t.Errorf("eventMeta = %v", meta)
+	}
+}
@@ -0,0 +1,51 @@
+package scraper
+
+import "regexp"
+
+// DefaultMatchPattern matches the event name of a fixture, e.g. "Atlético de Madrid vs. Real Madrid CF"
+const DefaultMatchPattern = `(?i)\svs\.?\s`
+
+// defaultMatchPattern is DefaultMatchPattern compiled
+var defaultMatchPattern = regexp.MustCompile(DefaultMatchPattern)
+
+// FilterMatchesOnly keeps events whose name looks like a fixture by matching
+// pattern, dropping stadium tours, museum visits and other listings. A nil
+// pattern uses DefaultMatchPattern.
+func (r *ScrapingResult) FilterMatchesOnly(pattern *regexp.Regexp) *ScrapingResult {
+	if pattern == nil {
+		pattern = defaultMatchPattern
+	}
+	return r.FilterByNamePatterns([]*regexp.Regexp{pattern}, nil)
+}
+
+// FilterByNamePatterns keeps events whose name matches at least one of the
+// allow patterns (any name when there are none) and none of the deny patterns
+func (r *ScrapingResult) FilterByNamePatterns(allow, deny []*regexp.Regexp) *ScrapingResult {
+	if len(allow) == 0 && len(deny) == 0 {
+		return r
+	}
+
+	filtered := r.withEvents([]TicketEvent{})
+
+	for _, event := range r.Events {
+		if matchesAny(allow, event.Event, true) && !matchesAny(deny, event.Event, false) {
+			filtered.Events = append(filtered.Events, event)
+		}
+	}
+
+	filtered.Total = len(filtered.Events)
+	return filtered
+}
+
+// matchesAny reports whether any of the patterns matches name, or ifEmpty when there are none
+func matchesAny(patterns []*regexp.Regexp, name string, ifEmpty bool) bool {
+	if len(patterns) == 0 {
+		return ifEmpty
+	}
+	for _, pattern := range patterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package scraper
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func nameFilterResult() *ScrapingResult {
+	return &ScrapingResult{Events: []TicketEvent{
+		{Event: "Atlético de Madrid vs. Real Madrid CF"},
+		{Event: "Bernabéu Stadium Tour"},
+		{Event: "Real Madrid vs Getafe"},
+		{Event: "Real Madrid Museum and Trophy Room"},
+		{Event: "Real Madrid VS Sevilla"},
+	}}
+}
+
+func TestFilterMatchesOnlyDropsToursAndMuseums(t *testing.T) {
+	got := eventNames(nameFilterResult().FilterMatchesOnly(nil))
+	want := []string{"Atlético de Madrid vs. Real Madrid CF", "Real Madrid vs Getafe", "Real Madrid VS Sevilla"}
+	if !slices.Equal(got, want) {
+		t.Errorf("FilterMatchesOnly(nil) = %q, want %q", got, want)
+	}
+
+	// A configured pattern replaces the default
+	got = eventNames(nameFilterResult().FilterMatchesOnly(regexp.MustCompile(`Tour`)))
+	if !slices.Equal(got, []string{"Bernabéu Stadium Tour"}) {
+		t.Errorf("FilterMatchesOnly(Tour) = %q", got)
+	}
+}
+
+func TestFilterByNamePatterns(t *testing.T) {
+	deny := []*regexp.Regexp{regexp.MustCompile(`(?i)tour|museum`)}
+	got := eventNames(nameFilterResult().FilterByNamePatterns(nil, deny))
+	if want := []string{"Atlético de Madrid vs. Real Madrid CF", "Real Madrid vs Getafe", "Real Madrid VS Sevilla"}; !slices.Equal(got, want) {
+		t.Errorf("deny = %q, want %q", got, want)
+	}
+
+	allow := []*regexp.Regexp{regexp.MustCompile(`Getafe`), regexp.MustCompile(`Sevilla`)}
+	got = eventNames(nameFilterResult().FilterByNamePatterns(allow, []*regexp.Regexp{regexp.MustCompile(`VS`)}))
+	if want := []string{"Real Madrid vs Getafe"}; !slices.Equal(got, want) {
+		t.Errorf("allow and deny = %q, want %q", got, want)
+	}
+
+	// No patterns keep everything
+	if got := nameFilterResult().FilterByNamePatterns(nil, nil); len(got.Events) != 5 {
+		t.Errorf("no patterns kept %d events, want 5", len(got.Events))
+	}
+}
@@ -1,9 +1,13 @@
 package scraper
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"math"
+	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/hbollon/go-edlib"
@@ -12,17 +16,91 @@ import (
 // TeamNameNormalizer handles team name normalization using AI-powered similarity
 type TeamNameNormalizer struct {
 	teamMappings        map[string]string
+	countryMappings     map[string]string // Only set when national team names are enabled
 	similarityThreshold float64
+	similarityGap       float64 // Minimum lead the best candidate needs over the runner-up
 }
 
 // NewTeamNameNormalizer creates a new team name normalizer
 func NewTeamNameNormalizer() *TeamNameNormalizer {
 	return &TeamNameNormalizer{
 		teamMappings:        getStandardTeamMappings(),
-		similarityThreshold: 0.7, // 70% similarity threshold
+		similarityThreshold: 0.7,  // 70% similarity threshold
+		similarityGap:       0.05, // Closer calls than this are treated as ambiguous
 	}
 }
 
+// NormalizerOptions configures how a normalizer is built
+type NormalizerOptions struct {
+	Strict        bool     // Fail instead of warning when mappings conflict with the defaults
+	NationalTeams bool     // Also recognise country names in several languages, e.g. "España" -> "Spain"
+	SimilarityGap *float64 // Minimum score lead over the next-best team for a fuzzy match; nil keeps the default and 0 turns the check off
+}
+
+// NewTeamNameNormalizerWithOptions creates a new team name normalizer with the given options
+func NewTeamNameNormalizerWithOptions(opts NormalizerOptions) *TeamNameNormalizer {
+	n := NewTeamNameNormalizer()
+	if opts.NationalTeams {
+		n.countryMappings = getNationalTeamMappings()
+	}
+	if opts.SimilarityGap != nil {
+		n.similarityGap = *opts.SimilarityGap
+	}
+	return n
+}
+
+// NewTeamNameNormalizerFromFile creates a normalizer whose default mappings are
+// extended by a JSON file of variation -> canonical name pairs
+func NewTeamNameNormalizerFromFile(path string, opts NormalizerOptions) (*TeamNameNormalizer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mappings file: %w", err)
+	}
+
+	var fileMappings map[string]string
+	if err := json.Unmarshal(data, &fileMappings); err != nil {
+		return nil, fmt.Errorf("failed to parse mappings file %s: %w", path, err)
+	}
+
+	n := NewTeamNameNormalizerWithOptions(opts)
+	if err := n.mergeMappings(fileMappings, opts.Strict); err != nil {
+		return nil, fmt.Errorf("invalid mappings file %s: %w", path, err)
+	}
+
+	return n, nil
+}
+
+// mergeMappings adds external mappings, checking overlapping keys for conflicting canonical names
+func (n *TeamNameNormalizer) mergeMappings(mappings map[string]string, strict bool) error {
+	var conflicts []string
+
+	for variation, canonical := range mappings {
+		key := strings.TrimSpace(strings.ToLower(variation))
+		canonical = strings.TrimSpace(canonical)
+		if key == "" || canonical == "" {
+			continue
+		}
+
+		if existing, exists := n.teamMappings[key]; exists && existing != canonical {
+			conflict := fmt.Sprintf("'%s' maps to '%s' but defaults use '%s'", key, canonical, existing)
+			if strict {
+				conflicts = append(conflicts, conflict)
+				continue
+			}
+			log.Printf("Warning: team mapping conflict: %s (using '%s')", conflict, canonical)
+		}
+
+		n.teamMappings[key] = canonical
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("%d conflicting team mappings: %s", len(conflicts), strings.Join(conflicts, "; "))
+	}
+
+	return nil
+}
+
 // getStandardTeamMappings returns a map of common team name variations to standard names
 func getStandardTeamMappings() map[string]string {
 	return map[string]string{
@@ -114,42 +192,97 @@ func getStandardTeamMappings() map[string]string {
 
 // NormalizeEvent normalizes a ticket event using AI-powered similarity matching
 func (n *TeamNameNormalizer) NormalizeEvent(event *TicketEvent) *TicketEvent {
-	normalized := &TicketEvent{
-		DateTime: n.normalizeDateTime(event.DateTime),
-		Event:    n.normalizeEventName(event.Event),
-		Link:     event.Link,   // Keep link as is
-		Source:   event.Source, // Keep source as is
+	return n.NormalizeEventWithConfidence(event, 0)
+}
+
+// NormalizeEventWithConfidence normalizes a ticket event, but keeps a team name
+// as listed when its fuzzy match scores below minConfidence. With a positive
+// minConfidence the lowest team score is recorded in NormalizedConfidence.
+func (n *TeamNameNormalizer) NormalizeEventWithConfidence(event *TicketEvent, minConfidence float64) *TicketEvent {
+	// Copy the event so link, source and any other fields are kept as is
+	normalized := *event
+	normalized.DateTime = n.normalizeDateTime(event.DateTime)
+	name, confidence := n.normalizeEventNameWithConfidence(event.Event, minConfidence)
+	normalized.Event = name
+	if minConfidence > 0 && confidence > 0 {
+		normalized.NormalizedConfidence = math.Round(confidence*100) / 100
 	}
-	return normalized
+	if _, parts := eventNameParts(event.Event); len(parts) > 2 {
+		normalized.Multi = true
+	}
+	// The name of a multi-match listing is kept as listed, so it may still hold
+	// one " vs " beside a "vs." and can't be split
+	if home, away, ok := splitTeams(normalized.Event); ok && !normalized.Multi {
+		normalized.HomeTeam = home
+		normalized.AwayTeam = away
+	}
+	return &normalized
 }
 
 // normalizeEventName normalizes the event name using team name mapping and similarity
 func (n *TeamNameNormalizer) normalizeEventName(eventName string) string {
-	// Clean up the event name
-	cleaned := strings.TrimSpace(eventName)
+	name, _ := n.normalizeEventNameWithConfidence(eventName, 0)
+	return name
+}
 
-	// Handle "vs" variations
-	cleaned = regexp.MustCompile(`\bvs\.?\b`).ReplaceAllString(cleaned, "vs")
-	cleaned = regexp.MustCompile(`\bv\b`).ReplaceAllString(cleaned, "vs")
+// normalizeEventNameWithConfidence normalizes the event name, keeping teams
+// whose match scores below minConfidence as listed. It also returns the lower
+// of the two team scores, or 0 when the name isn't a single match.
+func (n *TeamNameNormalizer) normalizeEventNameWithConfidence(eventName string, minConfidence float64) (string, float64) {
+	cleaned, parts := eventNameParts(eventName)
 
-	// Split by "vs" to get teams
-	parts := strings.Split(cleaned, "vs")
+	// A listing covering several matches, e.g. a tournament day, has no single
+	// home and away team, so its name is kept as listed
+	if len(parts) > 2 {
+		return strings.TrimSpace(eventName), 0
+	}
 	if len(parts) != 2 {
-		return cleaned // Return as is if not a standard match format
+		return cleaned, 0 // Return as is if not a standard match format
 	}
 
 	homeTeam := strings.TrimSpace(parts[0])
 	awayTeam := strings.TrimSpace(parts[1])
 
 	// Normalize team names
-	normalizedHome := n.normalizeTeamName(homeTeam)
-	normalizedAway := n.normalizeTeamName(awayTeam)
+	normalizedHome, homeScore := n.matchTeamName(homeTeam, minConfidence)
+	normalizedAway, awayScore := n.matchTeamName(awayTeam, minConfidence)
+
+	return fmt.Sprintf("%s vs %s", normalizedHome, normalizedAway), min(homeScore, awayScore)
+}
+
+// eventNameParts tidies the "vs" variations of an event name and splits it on
+// them; a single match has two parts
+func eventNameParts(eventName string) (string, []string) {
+	// Clean up the event name
+	cleaned := strings.TrimSpace(eventName)
+
+	// Handle "vs" variations
+	cleaned = regexp.MustCompile(`\bvs\.?\b`).ReplaceAllString(cleaned, "vs")
+	cleaned = regexp.MustCompile(`\bv\b`).ReplaceAllString(cleaned, "vs")
+
+	// Split by "vs" to get teams
+	return cleaned, strings.Split(cleaned, "vs")
+}
 
-	return fmt.Sprintf("%s vs %s", normalizedHome, normalizedAway)
+// splitTeams splits a normalized "Home vs Away" event name into its teams
+func splitTeams(eventName string) (string, string, bool) {
+	parts := strings.Split(eventName, " vs ")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), true
 }
 
 // normalizeTeamName normalizes a team name using mapping and similarity
 func (n *TeamNameNormalizer) normalizeTeamName(teamName string) string {
+	name, _ := n.matchTeamName(teamName, 0)
+	return name
+}
+
+// matchTeamName normalizes a team name and returns how confident the match is:
+// 1 for a mapped name, the similarity score for a fuzzy match and 0 for none.
+// A fuzzy match scoring below minConfidence leaves the name as listed.
+func (n *TeamNameNormalizer) matchTeamName(teamName string, minConfidence float64) (string, float64) {
 	// Clean the team name
 	cleaned := strings.TrimSpace(strings.ToLower(teamName))
 
@@ -159,51 +292,82 @@ func (n *TeamNameNormalizer) normalizeTeamName(teamName string) string {
 
 	// Check direct mapping first
 	if normalized, exists := n.teamMappings[cleaned]; exists {
-		return normalized
+		return normalized, 1
+	}
+
+	// Country names are matched exactly (accents folded) so they never fuzzy-match a club
+	if normalized, exists := n.countryMappings[foldAccents(cleaned)]; exists {
+		return normalized, 1
 	}
 
 	// Use AI-powered similarity matching
-	bestMatch := n.findBestSimilarTeam(cleaned)
+	bestMatch, score := n.findBestSimilarTeam(cleaned)
+	if bestMatch != "" && score >= minConfidence {
+		log.Printf("Normalized '%s' to '%s' (similarity: %.2f)", cleaned, bestMatch, score)
+		return bestMatch, score
+	}
+
+	// A borderline match is considered but not applied
 	if bestMatch != "" {
-		return bestMatch
+		log.Printf("Keeping '%s' as listed: '%s' (%.2f) is below the minimum confidence %.2f", teamName, bestMatch, score, minConfidence)
+		return strings.TrimSpace(teamName), score
 	}
 
 	// If no match found, return original with proper capitalization
-	return strings.Title(teamName)
+	return titleCaseTeam(teamName), 0
 }
 
-// findBestSimilarTeam finds the best matching team using similarity algorithms
-func (n *TeamNameNormalizer) findBestSimilarTeam(teamName string) string {
-	bestMatch := ""
-	bestScore := 0.0
-
-	for mappedTeam := range n.teamMappings {
-		// Try multiple similarity algorithms
-		levenshteinScore, _ := edlib.StringsSimilarity(teamName, mappedTeam, edlib.Levenshtein)
-		jaroScore, _ := edlib.StringsSimilarity(teamName, mappedTeam, edlib.Jaro)
-		jaroWinklerScore, _ := edlib.StringsSimilarity(teamName, mappedTeam, edlib.JaroWinkler)
-
-		// Convert to float64 and use the best score from all algorithms
-		maxScore := float64(levenshteinScore)
-		if float64(jaroScore) > maxScore {
-			maxScore = float64(jaroScore)
-		}
-		if float64(jaroWinklerScore) > maxScore {
-			maxScore = float64(jaroWinklerScore)
+// findBestSimilarTeam finds the best matching team using similarity algorithms.
+// When another team scores within the similarity gap of the best one the match
+// is ambiguous and no team is returned. The best team's score is returned with it.
+func (n *TeamNameNormalizer) findBestSimilarTeam(teamName string) (string, float64) {
+	// Best score per canonical team, since many variations map to the same team
+	scores := make(map[string]float64)
+	for mappedTeam, canonical := range n.teamMappings {
+		if score := similarity(teamName, mappedTeam); score > scores[canonical] {
+			scores[canonical] = score
 		}
+	}
 
-		if maxScore > bestScore && maxScore >= n.similarityThreshold {
-			bestScore = maxScore
-			bestMatch = n.teamMappings[mappedTeam]
+	bestMatch, runnerUp := "", ""
+	bestScore, runnerUpScore := 0.0, 0.0
+	for canonical, score := range scores {
+		if score > bestScore || (score == bestScore && canonical < bestMatch) {
+			runnerUp, runnerUpScore = bestMatch, bestScore
+			bestMatch, bestScore = canonical, score
+		} else if score > runnerUpScore {
+			runnerUp, runnerUpScore = canonical, score
 		}
 	}
 
-	if bestScore >= n.similarityThreshold {
-		log.Printf("Normalized '%s' to '%s' (similarity: %.2f)", teamName, bestMatch, bestScore)
-		return bestMatch
+	if bestScore < n.similarityThreshold {
+		return "", 0
+	}
+
+	if runnerUp != "" && bestScore-runnerUpScore < n.similarityGap {
+		log.Printf("Ambiguous team name '%s': '%s' (%.2f) and '%s' (%.2f) are too close, leaving it as is",
+			teamName, bestMatch, bestScore, runnerUp, runnerUpScore)
+		return "", 0
 	}
 
-	return ""
+	return bestMatch, bestScore
+}
+
+// similarity scores two strings with multiple algorithms and returns the best score
+func similarity(a, b string) float64 {
+	levenshteinScore, _ := edlib.StringsSimilarity(a, b, edlib.Levenshtein)
+	jaroScore, _ := edlib.StringsSimilarity(a, b, edlib.Jaro)
+	jaroWinklerScore, _ := edlib.StringsSimilarity(a, b, edlib.JaroWinkler)
+
+	// Convert to float64 and use the best score from all algorithms
+	maxScore := float64(levenshteinScore)
+	if float64(jaroScore) > maxScore {
+		maxScore = float64(jaroScore)
+	}
+	if float64(jaroWinklerScore) > maxScore {
+		maxScore = float64(jaroWinklerScore)
+	}
+	return maxScore
 }
 
 // normalizeDateTime normalizes date/time format
@@ -220,17 +384,45 @@ func (n *TeamNameNormalizer) normalizeDateTime(dateTime string) string {
 
 // NormalizeScrapingResult normalizes all events in a scraping result
 func (n *TeamNameNormalizer) NormalizeScrapingResult(result *ScrapingResult) *ScrapingResult {
-	normalized := &ScrapingResult{
-		Events:    make([]TicketEvent, len(result.Events)),
-		Total:     result.Total,
-		Timestamp: result.Timestamp,
-		SourceURL: result.SourceURL,
-		Source:    result.Source,
-	}
+	return n.NormalizeScrapingResultWithConfidence(result, 0)
+}
+
+// NormalizeScrapingResultWithConfidence normalizes all events in a scraping
+// result, keeping team names whose match scores below minConfidence
+func (n *TeamNameNormalizer) NormalizeScrapingResultWithConfidence(result *ScrapingResult, minConfidence float64) *ScrapingResult {
+	normalized := result.withEvents(make([]TicketEvent, len(result.Events)))
 
 	for i, event := range result.Events {
-		normalized.Events[i] = *n.NormalizeEvent(&event)
+		normalized.Events[i] = *n.NormalizeEventWithConfidence(&event, minConfidence)
 	}
 
 	return normalized
 }
+
+// NormalizeForConfidence normalizes all events in a scraping result once,
+// recording each match's confidence, so that WithMinConfidence can apply any
+// minimum confidence to it later without matching every team again. No team
+// is kept as listed, since every fuzzy match scores at least the similarity threshold.
+func (n *TeamNameNormalizer) NormalizeForConfidence(result *ScrapingResult) *ScrapingResult {
+	return n.NormalizeScrapingResultWithConfidence(result, n.similarityThreshold)
+}
+
+// WithMinConfidence returns what NormalizeScrapingResultWithConfidence gives
+// for raw and minConfidence, reusing normalized, which NormalizeForConfidence
+// made from raw. Only events matched with about minConfidence or less are
+// normalized again.
+func (n *TeamNameNormalizer) WithMinConfidence(raw, normalized *ScrapingResult, minConfidence float64) *ScrapingResult {
+	cut := normalized.withEvents(make([]TicketEvent, len(normalized.Events)))
+	for i, event := range normalized.Events {
+		switch {
+		case minConfidence <= 0:
+			// Confidence is only reported when a minimum was asked for
+			event.NormalizedConfidence = raw.Events[i].NormalizedConfidence
+		case event.NormalizedConfidence > 0 && event.NormalizedConfidence < minConfidence+0.005:
+			// The recorded confidence is rounded, so matches just above the cut are checked again too
+			event = *n.NormalizeEventWithConfidence(&raw.Events[i], minConfidence)
+		}
+		cut.Events[i] = event
+	}
+	return cut
+}
@@ -0,0 +1,131 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNationalTeamAliases(t *testing.T) {
+	n := NewTeamNameNormalizerWithOptions(NormalizerOptions{NationalTeams: true})
+
+	tests := map[string]string{
+		"Spain":          "Spain",
+		"España":         "Spain",
+		"Espagne":        "Spain",
+		"Spanien":        "Spain",
+		"Deutschland":    "Germany",
+		"Allemagne":      "Germany",
+		"Países Bajos":   "Netherlands",
+		"Brésil":         "Brazil",
+		"Estados Unidos": "United States",
+	}
+	for alias, want := range tests {
+		if got := n.normalizeTeamName(alias); got != want {
+			t.Errorf("normalizeTeamName(%q) = %q, want %q", alias, got, want)
+		}
+	}
+
+	// Clubs keep their usual names
+	if got := n.normalizeTeamName("Real Madrid CF"); got != "Real Madrid" {
+		t.Errorf("normalizeTeamName(%q) = %q, want %q", "Real Madrid CF", got, "Real Madrid")
+	}
+}
+
+func TestNationalTeamsOffByDefault(t *testing.T) {
+	n := NewTeamNameNormalizer()
+	for alias, country := range map[string]string{"España": "Spain", "Deutschland": "Germany"} {
+		if got := n.normalizeTeamName(alias); got == country {
+			t.Errorf("normalizeTeamName(%q) = %q without national teams", alias, got)
+		}
+	}
+}
+
+func TestSimilarityGapSkipsAmbiguousNames(t *testing.T) {
+	// "manchester" scores almost the same against United and City
+	const ambiguous = "Manchester"
+
+	n := NewTeamNameNormalizer()
+	if got := n.normalizeTeamName(ambiguous); got != ambiguous {
+		t.Errorf("normalizeTeamName(%q) = %q with the default gap, want it unchanged", ambiguous, got)
+	}
+
+	zero := 0.0
+	n = NewTeamNameNormalizerWithOptions(NormalizerOptions{SimilarityGap: &zero})
+	if got := n.normalizeTeamName(ambiguous); got != "Manchester United" {
+		t.Errorf("normalizeTeamName(%q) = %q with the gap off, want the best match", ambiguous, got)
+	}
+
+	// A clear winner is matched whatever the gap
+	if got := NewTeamNameNormalizer().normalizeTeamName("sevila"); got != "Sevilla" {
+		t.Errorf("normalizeTeamName(%q) = %q, want Sevilla", "sevila", got)
+	}
+}
+
+func TestMultiMatchListingKeptAsListed(t *testing.T) {
+	n := NewTeamNameNormalizer()
+	for _, name := range []string{"Team A vs Team B vs Team C", "Real Madrid CF vs. Getafe vs Sevilla FC"} {
+		event := n.NormalizeEvent(&TicketEvent{Event: name})
+		if event.Event != name {
+			t.Errorf("NormalizeEvent(%q).Event = %q, want the name as listed", name, event.Event)
+		}
+		if !event.Multi {
+			t.Errorf("NormalizeEvent(%q) not flagged multi", name)
+		}
+		if event.HomeTeam != "" || event.AwayTeam != "" {
+			t.Errorf("NormalizeEvent(%q) set teams %q and %q", name, event.HomeTeam, event.AwayTeam)
+		}
+	}
+
+	// A single match is still normalized and split
+	event := n.NormalizeEvent(&TicketEvent{Event: "Real Madrid CF vs. Getafe"})
+	if event.Multi || event.HomeTeam != "Real Madrid" || event.AwayTeam != "Getafe" {
+		t.Errorf("single match normalized to %+v", event)
+	}
+}
+
+func TestWithMinConfidenceMatchesFullNormalization(t *testing.T) {
+	n := NewTeamNameNormalizer()
+	raw := &ScrapingResult{Events: []TicketEvent{
+		{Event: "Real Madrid vs Sevila"},
+		{Event: "Real Madrid CF vs. Getafe CF"},
+		{Event: "Atletico vs Real Madrid vs Sevilla"},
+		{Event: "Copa del Rey tickets"},
+	}}
+	normalized := n.NormalizeForConfidence(raw)
+
+	for _, minConfidence := range []float64{0, 0.5, 0.75, 0.8, 0.85, 0.9, 0.99, 1} {
+		want := n.NormalizeScrapingResultWithConfidence(raw, minConfidence)
+		got := n.WithMinConfidence(raw, normalized, minConfidence)
+		if !reflect.DeepEqual(got.Events, want.Events) {
+			t.Errorf("minConfidence %g: got %+v, want %+v", minConfidence, got.Events, want.Events)
+		}
+	}
+}
+
+func TestMinConfidenceKeepsBorderlineMatches(t *testing.T) {
+	n := NewTeamNameNormalizer()
+	const listed = "Real Madrid vs Sevila"
+
+	_, score := n.matchTeamName("Sevila", 0)
+	if score <= 0.75 || score >= 0.99 {
+		t.Fatalf("Sevila scores %.2f, want a borderline match between 0.75 and 0.99", score)
+	}
+
+	strict := n.NormalizeEventWithConfidence(&TicketEvent{Event: listed}, 0.99)
+	if strict.Event != listed {
+		t.Errorf("with minConfidence 0.99 got %q, want %q kept", strict.Event, listed)
+	}
+	if strict.NormalizedConfidence <= 0 || strict.NormalizedConfidence >= 0.99 {
+		t.Errorf("NormalizedConfidence = %.2f, want the borderline score", strict.NormalizedConfidence)
+	}
+
+	loose := n.NormalizeEventWithConfidence(&TicketEvent{Event: listed}, 0.75)
+	if loose.Event != "Real Madrid vs Sevilla" {
+		t.Errorf("with minConfidence 0.75 got %q, want it normalized", loose.Event)
+	}
+
+	// Without a minimum the confidence isn't reported
+	if event := n.NormalizeEvent(&TicketEvent{Event: listed}); event.NormalizedConfidence != 0 {
+		t.Errorf("NormalizedConfidence = %.2f without a minimum", event.NormalizedConfidence)
+	}
+}
@@ -0,0 +1,43 @@
+package scraper
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+)
+
+// loadFixture parses a saved HTML page from disk
+func loadFixture(path string) (*goquery.Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open fixture: %w", err)
+	}
+	defer f.Close()
+
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+
+	return doc, nil
+}
+
+// forEachFixtureElement calls fn for every element of the fixture matching
+// selector, wrapped as a colly element so the live parsers can be reused
+func forEachFixtureElement(path, selector string, fn func(e *colly.HTMLElement)) error {
+	doc, err := loadFixture(path)
+	if err != nil {
+		return err
+	}
+
+	resp := &colly.Response{}
+	doc.Find(selector).Each(func(i int, sel *goquery.Selection) {
+		for _, node := range sel.Nodes {
+			fn(colly.NewHTMLElementFromSelectionNode(resp, sel, node, i))
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package scraper
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultUserAgent is the User-Agent sent when the options don't set one
+const DefaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"
+
+// ScraperOptions holds the tunables shared by all scrapers
+type ScraperOptions struct {
+	RequestDelay time.Duration // Delay between requests to the same site
+	Timeout      time.Duration // Maximum time for a single scrape request
+	Selectors    Selectors     // Overrides for the source's built-in selectors
+	Limiter      *rate.Limiter // Global request budget shared across scrapers; nil means unlimited
+	MaxPages     int           // Listing pages to follow on paginated sources; 1 scrapes only the first page
+	Language     string        // Sent as Accept-Language so sites return stable (English) team names
+	CaptureRaw   bool          // Keep each listing's outer HTML in RawHTML; off by default as it bloats results
+	UserAgent    string        // Sent with every request; empty uses DefaultUserAgent
+	RetryEmpty   bool          // Scrape HelloTickets and VividSeats once more when no events were parsed
+	MaxRedirects *int          // Redirects followed per request; nil keeps the default of 10 and 0 stops at the first redirect
+	Warmup       bool          // Visit the HelloTickets or VividSeats homepage first to pick up cookies
+
+	UnexpectedRedirects []string // Path prefixes a redirect must not lead to, e.g. "/region-select"
+	SkipWarnRatio       float64  // Share of skipped listing elements above which a scrape logs a warning; 0 never warns
+
+	Credentials Credentials // Caller-supplied cookies and basic auth, sent only to the source's domain
+
+	VividSeatsPerformers []string            // VividSeats performer IDs whose pages are scraped and merged; nil uses DefaultVividSeatsPerformers
+	ListingTypeKeywords  map[string][]string // Title keywords marking non-match listings, keyed by type; nil uses the defaults
+}
+
+// DefaultScraperOptions returns the options used by the plain constructors
+func DefaultScraperOptions() ScraperOptions {
+	return ScraperOptions{
+		RequestDelay: 1 * time.Second,
+		Timeout:      30 * time.Second,
+		MaxPages:     1,
+		Language:     "en",
+		UserAgent:    DefaultUserAgent,
+
+		ListingTypeKeywords: DefaultListingTypeKeywords(),
+		SkipWarnRatio:       DefaultSkipWarnRatio,
+	}
+}
+
+// userAgent returns the configured User-Agent, falling back to DefaultUserAgent
+func (o ScraperOptions) userAgent() string {
+	if o.UserAgent == "" {
+		return DefaultUserAgent
+	}
+	return o.UserAgent
+}
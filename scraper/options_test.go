@@ -0,0 +1,42 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestScrapersSendUserAgent(t *testing.T) {
+	for _, tc := range []struct {
+		configured string
+		want       string
+	}{
+		{"test-agent/1.0", "test-agent/1.0"},
+		{"", DefaultUserAgent},
+	} {
+		opts := testOptions()
+		opts.UserAgent = tc.configured
+		opts.VividSeatsPerformers = []string{"11111"}
+
+		hello := NewScraperWithOptions(opts)
+		vivid := NewVividSeatsScraperWithOptions(opts)
+		for _, s := range []struct {
+			SourceScraper
+			mock func(http.Handler)
+		}{
+			{hello, func(h http.Handler) { mockSite(t, hello.collector, h) }},
+			{vivid, func(h http.Handler) { mockSite(t, vivid.collector, h) }},
+		} {
+			var got string
+			s.mock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.UserAgent()
+			}))
+			if _, err := s.Scrape(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("%s sent User-Agent %q with %q configured, want %q", s.Name(), got, tc.configured, tc.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package scraper
+
+import "time"
+
+// FlagPastEvents marks events dated before today as Past without dropping them.
+// A multi-day event is past only once its last day is. Events whose date can't
+// be read are left unflagged.
+func (r *ScrapingResult) FlagPastEvents() *ScrapingResult {
+	// Event dates are parsed without a zone, so today is in the same naive UTC terms
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	events := make([]TicketEvent, len(r.Events))
+	for i, event := range r.Events {
+		if _, end, ok := event.dateSpan(time.UTC); ok {
+			event.Past = end.Before(today)
+		} else if date, err := parseEventDate(event.DateTime); err == nil && date.Before(today) {
+			event.Past = true
+		}
+		events[i] = event
+	}
+	return r.withEvents(events)
+}
+
+// WithoutPastEvents drops the events flagged by FlagPastEvents
+func (r *ScrapingResult) WithoutPastEvents() *ScrapingResult {
+	kept := r.withEvents([]TicketEvent{})
+	for _, event := range r.Events {
+		if !event.Past {
+			kept.Events = append(kept.Events, event)
+		}
+	}
+	kept.Total = len(kept.Events)
+	return kept
+}
@@ -0,0 +1,48 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlagPastEvents(t *testing.T) {
+	// A tournament that started two days ago and ends tomorrow
+	today := time.Now().UTC()
+	ongoingStart := today.AddDate(0, 0, -2).Format(eventDayLayout)
+	ongoingEnd := today.AddDate(0, 0, 1).Format(eventDayLayout)
+
+	result := &ScrapingResult{Events: []TicketEvent{
+		{Event: "past", DateTime: "27 Sep 2020"},
+		{Event: "future", DateTime: "27 Sep 2099"},
+		{Event: "past ISO", DateTime: "2021-03-14"},
+		{Event: "unreadable", DateTime: "TBD"},
+		{Event: "no year", DateTime: "27 Sep"},
+		{Event: "ongoing", DateTime: ongoingStart, StartDate: ongoingStart, EndDate: ongoingEnd},
+		{Event: "ended", DateTime: "2021-03-12", StartDate: "2021-03-12", EndDate: "2021-03-14"},
+	}}
+	result.Total = len(result.Events)
+
+	flagged := result.FlagPastEvents()
+	want := map[string]bool{"past": true, "past ISO": true, "ended": true}
+	for _, event := range flagged.Events {
+		if event.Past != want[event.Event] {
+			t.Errorf("%s (%q): Past = %v, want %v", event.Event, event.DateTime, event.Past, want[event.Event])
+		}
+	}
+	if flagged.Total != 7 {
+		t.Errorf("FlagPastEvents kept %d events, want all 7", flagged.Total)
+	}
+	if result.Events[0].Past {
+		t.Error("FlagPastEvents changed the original events")
+	}
+
+	kept := flagged.WithoutPastEvents()
+	if kept.Total != 4 {
+		t.Fatalf("WithoutPastEvents kept %d events, want 4", kept.Total)
+	}
+	for _, event := range kept.Events {
+		if want[event.Event] {
+			t.Errorf("WithoutPastEvents kept %q", event.Event)
+		}
+	}
+}
@@ -0,0 +1,636 @@
+// Protocol Buffers schema of the format=protobuf output. The Go structs in
+// types.go are the source of truth; protobuf.go maps them to and from the
+// messages generated into scraper/pb (go generate ./scraper).
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: scraping_result.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ScrapingResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Events            []*TicketEvent         `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Total             int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Timestamp         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	SourceUrl         string                 `protobuf:"bytes,4,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	Source            string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	SourceStatus      map[string]string      `protobuf:"bytes,6,rep,name=source_status,json=sourceStatus,proto3" json:"source_status,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RawTotal          int32                  `protobuf:"varint,7,opt,name=raw_total,json=rawTotal,proto3" json:"raw_total,omitempty"`
+	DuplicatesRemoved int32                  `protobuf:"varint,8,opt,name=duplicates_removed,json=duplicatesRemoved,proto3" json:"duplicates_removed,omitempty"`
+	Errors            []*ScrapeError         `protobuf:"bytes,9,rep,name=errors,proto3" json:"errors,omitempty"`
+	Partial           bool                   `protobuf:"varint,10,opt,name=partial,proto3" json:"partial,omitempty"`
+	ServedBy          string                 `protobuf:"bytes,11,opt,name=served_by,json=servedBy,proto3" json:"served_by,omitempty"`
+	SourceUrls        map[string]string      `protobuf:"bytes,12,rep,name=source_urls,json=sourceUrls,proto3" json:"source_urls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ScrapingResult) Reset() {
+	*x = ScrapingResult{}
+	mi := &file_scraping_result_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrapingResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrapingResult) ProtoMessage() {}
+
+func (x *ScrapingResult) ProtoReflect() protoreflect.Message {
+	mi := &file_scraping_result_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrapingResult.ProtoReflect.Descriptor instead.
+func (*ScrapingResult) Descriptor() ([]byte, []int) {
+	return file_scraping_result_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ScrapingResult) GetEvents() []*TicketEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ScrapingResult) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ScrapingResult) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ScrapingResult) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *ScrapingResult) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ScrapingResult) GetSourceStatus() map[string]string {
+	if x != nil {
+		return x.SourceStatus
+	}
+	return nil
+}
+
+func (x *ScrapingResult) GetRawTotal() int32 {
+	if x != nil {
+		return x.RawTotal
+	}
+	return 0
+}
+
+func (x *ScrapingResult) GetDuplicatesRemoved() int32 {
+	if x != nil {
+		return x.DuplicatesRemoved
+	}
+	return 0
+}
+
+func (x *ScrapingResult) GetErrors() []*ScrapeError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ScrapingResult) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *ScrapingResult) GetServedBy() string {
+	if x != nil {
+		return x.ServedBy
+	}
+	return ""
+}
+
+func (x *ScrapingResult) GetSourceUrls() map[string]string {
+	if x != nil {
+		return x.SourceUrls
+	}
+	return nil
+}
+
+type ScrapeError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScrapeError) Reset() {
+	*x = ScrapeError{}
+	mi := &file_scraping_result_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrapeError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrapeError) ProtoMessage() {}
+
+func (x *ScrapeError) ProtoReflect() protoreflect.Message {
+	mi := &file_scraping_result_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrapeError.ProtoReflect.Descriptor instead.
+func (*ScrapeError) Descriptor() ([]byte, []int) {
+	return file_scraping_result_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScrapeError) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ScrapeError) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ScrapeError) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type TicketEvent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Datetime             string                 `protobuf:"bytes,1,opt,name=datetime,proto3" json:"datetime,omitempty"`
+	Event                string                 `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	Link                 string                 `protobuf:"bytes,3,opt,name=link,proto3" json:"link,omitempty"`
+	Source               string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	HomeTeam             string                 `protobuf:"bytes,5,opt,name=home_team,json=homeTeam,proto3" json:"home_team,omitempty"`
+	AwayTeam             string                 `protobuf:"bytes,6,opt,name=away_team,json=awayTeam,proto3" json:"away_team,omitempty"`
+	Round                string                 `protobuf:"bytes,7,opt,name=round,proto3" json:"round,omitempty"`
+	Competition          string                 `protobuf:"bytes,8,opt,name=competition,proto3" json:"competition,omitempty"`
+	OriginalIndex        int32                  `protobuf:"varint,9,opt,name=original_index,json=originalIndex,proto3" json:"original_index,omitempty"`
+	ImageUrl             string                 `protobuf:"bytes,10,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	OriginalLink         string                 `protobuf:"bytes,11,opt,name=original_link,json=originalLink,proto3" json:"original_link,omitempty"`
+	Type                 string                 `protobuf:"bytes,12,opt,name=type,proto3" json:"type,omitempty"`
+	Status               string                 `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	Multi                bool                   `protobuf:"varint,14,opt,name=multi,proto3" json:"multi,omitempty"`
+	DoorsTime            string                 `protobuf:"bytes,15,opt,name=doors_time,json=doorsTime,proto3" json:"doors_time,omitempty"`
+	DateUnparsed         bool                   `protobuf:"varint,16,opt,name=date_unparsed,json=dateUnparsed,proto3" json:"date_unparsed,omitempty"`
+	Past                 bool                   `protobuf:"varint,17,opt,name=past,proto3" json:"past,omitempty"`
+	Venue                string                 `protobuf:"bytes,18,opt,name=venue,proto3" json:"venue,omitempty"`
+	Availability         string                 `protobuf:"bytes,19,opt,name=availability,proto3" json:"availability,omitempty"`
+	Price                string                 `protobuf:"bytes,20,opt,name=price,proto3" json:"price,omitempty"`
+	PriceValue           float64                `protobuf:"fixed64,21,opt,name=price_value,json=priceValue,proto3" json:"price_value,omitempty"`
+	PriceMin             float64                `protobuf:"fixed64,22,opt,name=price_min,json=priceMin,proto3" json:"price_min,omitempty"`
+	PriceMax             float64                `protobuf:"fixed64,23,opt,name=price_max,json=priceMax,proto3" json:"price_max,omitempty"`
+	Currency             string                 `protobuf:"bytes,24,opt,name=currency,proto3" json:"currency,omitempty"`
+	ListingCount         int32                  `protobuf:"varint,25,opt,name=listing_count,json=listingCount,proto3" json:"listing_count,omitempty"`
+	NormalizedConfidence float64                `protobuf:"fixed64,26,opt,name=normalized_confidence,json=normalizedConfidence,proto3" json:"normalized_confidence,omitempty"`
+	ScrapedAt            *timestamppb.Timestamp `protobuf:"bytes,27,opt,name=scraped_at,json=scrapedAt,proto3" json:"scraped_at,omitempty"`
+	Meta                 map[string]string      `protobuf:"bytes,28,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RawHtml              string                 `protobuf:"bytes,29,opt,name=raw_html,json=rawHtml,proto3" json:"raw_html,omitempty"`
+	StartDate            string                 `protobuf:"bytes,30,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate              string                 `protobuf:"bytes,31,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TicketEvent) Reset() {
+	*x = TicketEvent{}
+	mi := &file_scraping_result_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketEvent) ProtoMessage() {}
+
+func (x *TicketEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_scraping_result_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketEvent.ProtoReflect.Descriptor instead.
+func (*TicketEvent) Descriptor() ([]byte, []int) {
+	return file_scraping_result_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TicketEvent) GetDatetime() string {
+	if x != nil {
+		return x.Datetime
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetHomeTeam() string {
+	if x != nil {
+		return x.HomeTeam
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetAwayTeam() string {
+	if x != nil {
+		return x.AwayTeam
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetRound() string {
+	if x != nil {
+		return x.Round
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetCompetition() string {
+	if x != nil {
+		return x.Competition
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetOriginalIndex() int32 {
+	if x != nil {
+		return x.OriginalIndex
+	}
+	return 0
+}
+
+func (x *TicketEvent) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetOriginalLink() string {
+	if x != nil {
+		return x.OriginalLink
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetMulti() bool {
+	if x != nil {
+		return x.Multi
+	}
+	return false
+}
+
+func (x *TicketEvent) GetDoorsTime() string {
+	if x != nil {
+		return x.DoorsTime
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetDateUnparsed() bool {
+	if x != nil {
+		return x.DateUnparsed
+	}
+	return false
+}
+
+func (x *TicketEvent) GetPast() bool {
+	if x != nil {
+		return x.Past
+	}
+	return false
+}
+
+func (x *TicketEvent) GetVenue() string {
+	if x != nil {
+		return x.Venue
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetAvailability() string {
+	if x != nil {
+		return x.Availability
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetPriceValue() float64 {
+	if x != nil {
+		return x.PriceValue
+	}
+	return 0
+}
+
+func (x *TicketEvent) GetPriceMin() float64 {
+	if x != nil {
+		return x.PriceMin
+	}
+	return 0
+}
+
+func (x *TicketEvent) GetPriceMax() float64 {
+	if x != nil {
+		return x.PriceMax
+	}
+	return 0
+}
+
+func (x *TicketEvent) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetListingCount() int32 {
+	if x != nil {
+		return x.ListingCount
+	}
+	return 0
+}
+
+func (x *TicketEvent) GetNormalizedConfidence() float64 {
+	if x != nil {
+		return x.NormalizedConfidence
+	}
+	return 0
+}
+
+func (x *TicketEvent) GetScrapedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScrapedAt
+	}
+	return nil
+}
+
+func (x *TicketEvent) GetMeta() map[string]string {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *TicketEvent) GetRawHtml() string {
+	if x != nil {
+		return x.RawHtml
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *TicketEvent) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+var File_scraping_result_proto protoreflect.FileDescriptor
+
+const file_scraping_result_proto_rawDesc = "" +
+	"\n" +
+	"\x15scraping_result.proto\x12\ascraper\x1a\x1fgoogle/protobuf/timestamp.proto\"\x90\x05\n" +
+	"\x0eScrapingResult\x12,\n" +
+	"\x06events\x18\x01 \x03(\v2\x14.scraper.TicketEventR\x06events\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x04 \x01(\tR\tsourceUrl\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12N\n" +
+	"\rsource_status\x18\x06 \x03(\v2).scraper.ScrapingResult.SourceStatusEntryR\fsourceStatus\x12\x1b\n" +
+	"\traw_total\x18\a \x01(\x05R\brawTotal\x12-\n" +
+	"\x12duplicates_removed\x18\b \x01(\x05R\x11duplicatesRemoved\x12,\n" +
+	"\x06errors\x18\t \x03(\v2\x14.scraper.ScrapeErrorR\x06errors\x12\x18\n" +
+	"\apartial\x18\n" +
+	" \x01(\bR\apartial\x12\x1b\n" +
+	"\tserved_by\x18\v \x01(\tR\bservedBy\x12H\n" +
+	"\vsource_urls\x18\f \x03(\v2'.scraper.ScrapingResult.SourceUrlsEntryR\n" +
+	"sourceUrls\x1a?\n" +
+	"\x11SourceStatusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a=\n" +
+	"\x0fSourceUrlsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"K\n" +
+	"\vScrapeError\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\"\xfe\a\n" +
+	"\vTicketEvent\x12\x1a\n" +
+	"\bdatetime\x18\x01 \x01(\tR\bdatetime\x12\x14\n" +
+	"\x05event\x18\x02 \x01(\tR\x05event\x12\x12\n" +
+	"\x04link\x18\x03 \x01(\tR\x04link\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x1b\n" +
+	"\thome_team\x18\x05 \x01(\tR\bhomeTeam\x12\x1b\n" +
+	"\taway_team\x18\x06 \x01(\tR\bawayTeam\x12\x14\n" +
+	"\x05round\x18\a \x01(\tR\x05round\x12 \n" +
+	"\vcompetition\x18\b \x01(\tR\vcompetition\x12%\n" +
+	"\x0eoriginal_index\x18\t \x01(\x05R\roriginalIndex\x12\x1b\n" +
+	"\timage_url\x18\n" +
+	" \x01(\tR\bimageUrl\x12#\n" +
+	"\roriginal_link\x18\v \x01(\tR\foriginalLink\x12\x12\n" +
+	"\x04type\x18\f \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x12\x14\n" +
+	"\x05multi\x18\x0e \x01(\bR\x05multi\x12\x1d\n" +
+	"\n" +
+	"doors_time\x18\x0f \x01(\tR\tdoorsTime\x12#\n" +
+	"\rdate_unparsed\x18\x10 \x01(\bR\fdateUnparsed\x12\x12\n" +
+	"\x04past\x18\x11 \x01(\bR\x04past\x12\x14\n" +
+	"\x05venue\x18\x12 \x01(\tR\x05venue\x12\"\n" +
+	"\favailability\x18\x13 \x01(\tR\favailability\x12\x14\n" +
+	"\x05price\x18\x14 \x01(\tR\x05price\x12\x1f\n" +
+	"\vprice_value\x18\x15 \x01(\x01R\n" +
+	"priceValue\x12\x1b\n" +
+	"\tprice_min\x18\x16 \x01(\x01R\bpriceMin\x12\x1b\n" +
+	"\tprice_max\x18\x17 \x01(\x01R\bpriceMax\x12\x1a\n" +
+	"\bcurrency\x18\x18 \x01(\tR\bcurrency\x12#\n" +
+	"\rlisting_count\x18\x19 \x01(\x05R\flistingCount\x123\n" +
+	"\x15normalized_confidence\x18\x1a \x01(\x01R\x14normalizedConfidence\x129\n" +
+	"\n" +
+	"scraped_at\x18\x1b \x01(\v2\x1a.google.protobuf.TimestampR\tscrapedAt\x122\n" +
+	"\x04meta\x18\x1c \x03(\v2\x1e.scraper.TicketEvent.MetaEntryR\x04meta\x12\x19\n" +
+	"\braw_html\x18\x1d \x01(\tR\arawHtml\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x1e \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x1f \x01(\tR\aendDate\x1a7\n" +
+	"\tMetaEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x17Z\x15normalizer/scraper/pbb\x06proto3"
+
+var (
+	file_scraping_result_proto_rawDescOnce sync.Once
+	file_scraping_result_proto_rawDescData []byte
+)
+
+func file_scraping_result_proto_rawDescGZIP() []byte {
+	file_scraping_result_proto_rawDescOnce.Do(func() {
+		file_scraping_result_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scraping_result_proto_rawDesc), len(file_scraping_result_proto_rawDesc)))
+	})
+	return file_scraping_result_proto_rawDescData
+}
+
+var file_scraping_result_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_scraping_result_proto_goTypes = []any{
+	(*ScrapingResult)(nil),        // 0: scraper.ScrapingResult
+	(*ScrapeError)(nil),           // 1: scraper.ScrapeError
+	(*TicketEvent)(nil),           // 2: scraper.TicketEvent
+	nil,                           // 3: scraper.ScrapingResult.SourceStatusEntry
+	nil,                           // 4: scraper.ScrapingResult.SourceUrlsEntry
+	nil,                           // 5: scraper.TicketEvent.MetaEntry
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_scraping_result_proto_depIdxs = []int32{
+	2, // 0: scraper.ScrapingResult.events:type_name -> scraper.TicketEvent
+	6, // 1: scraper.ScrapingResult.timestamp:type_name -> google.protobuf.Timestamp
+	3, // 2: scraper.ScrapingResult.source_status:type_name -> scraper.ScrapingResult.SourceStatusEntry
+	1, // 3: scraper.ScrapingResult.errors:type_name -> scraper.ScrapeError
+	4, // 4: scraper.ScrapingResult.source_urls:type_name -> scraper.ScrapingResult.SourceUrlsEntry
+	6, // 5: scraper.TicketEvent.scraped_at:type_name -> google.protobuf.Timestamp
+	5, // 6: scraper.TicketEvent.meta:type_name -> scraper.TicketEvent.MetaEntry
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_scraping_result_proto_init() }
+func file_scraping_result_proto_init() {
+	if File_scraping_result_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scraping_result_proto_rawDesc), len(file_scraping_result_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_scraping_result_proto_goTypes,
+		DependencyIndexes: file_scraping_result_proto_depIdxs,
+		MessageInfos:      file_scraping_result_proto_msgTypes,
+	}.Build()
+	File_scraping_result_proto = out.File
+	file_scraping_result_proto_goTypes = nil
+	file_scraping_result_proto_depIdxs = nil
+}
@@ -0,0 +1,31 @@
+package scraper
+
+import "sync"
+
+// postProcessors holds the functions registered for each source, in registration order
+var postProcessors = struct {
+	mu       sync.RWMutex
+	bySource map[string][]func(*ScrapingResult) *ScrapingResult
+}{bySource: make(map[string][]func(*ScrapingResult) *ScrapingResult)}
+
+// RegisterPostProcessor adds fn to the processors run, in registration order, over each result scraped from source
+func RegisterPostProcessor(source string, fn func(*ScrapingResult) *ScrapingResult) {
+	postProcessors.mu.Lock()
+	defer postProcessors.mu.Unlock()
+	postProcessors.bySource[source] = append(postProcessors.bySource[source], fn)
+}
+
+// PostProcess runs the processors registered for source over r
+func PostProcess(source string, r *ScrapingResult) *ScrapingResult {
+	postProcessors.mu.RLock()
+	processors := postProcessors.bySource[source]
+	postProcessors.mu.RUnlock()
+
+	for _, fn := range processors {
+		if processed := fn(r); processed != nil {
+			r = processed
+			r.Total = len(r.Events)
+		}
+	}
+	return r
+}
@@ -0,0 +1,47 @@
+package scraper
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPostProcessorsRunInOrder(t *testing.T) {
+	const source = "postprocess-test"
+	var order []string
+	RegisterPostProcessor(source, func(r *ScrapingResult) *ScrapingResult {
+		order = append(order, "drop promos")
+		var events []TicketEvent
+		for _, event := range r.Events {
+			if !strings.HasPrefix(event.Event, "PROMO") {
+				events = append(events, event)
+			}
+		}
+		return r.withEvents(events)
+	})
+	RegisterPostProcessor(source, func(r *ScrapingResult) *ScrapingResult {
+		order = append(order, "inspect")
+		return nil // Leaves the result as it is
+	})
+
+	result := PostProcess(source, &ScrapingResult{Events: []TicketEvent{
+		{Event: "Real Madrid vs Getafe"},
+		{Event: "PROMO Win a season ticket"},
+		{Event: "Sevilla vs Betis"},
+	}, Total: 3})
+
+	if want := []string{"drop promos", "inspect"}; !slices.Equal(order, want) {
+		t.Errorf("processors ran as %v, want %v", order, want)
+	}
+	if got := eventNames(result); !slices.Equal(got, []string{"Real Madrid vs Getafe", "Sevilla vs Betis"}) {
+		t.Errorf("events = %v, want the promo dropped", got)
+	}
+	if result.Total != 2 {
+		t.Errorf("Total = %d, want 2", result.Total)
+	}
+
+	other := &ScrapingResult{Events: []TicketEvent{{Event: "PROMO elsewhere"}}, Total: 1}
+	if PostProcess("another-source", other) != other || other.Total != 1 {
+		t.Error("processors ran over another source's result")
+	}
+}
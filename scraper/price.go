@@ -0,0 +1,139 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Price is a monetary amount that always marshals with two decimal places,
+// e.g. 120 as 120.00 and 99.989999 as 99.99
+type Price float64
+
+// String formats the price with two decimal places, rounding half up to the cent
+func (p Price) String() string {
+	// Round to tenths of a cent first so float noise such as 99.98499999 for
+	// 99.985 doesn't decide the rounding
+	cents := math.Round(math.Round(float64(p)*1000) / 10)
+	return strconv.FormatFloat(cents/100, 'f', 2, 64)
+}
+
+// MarshalJSON writes the price as a JSON number with two decimal places
+func (p Price) MarshalJSON() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// stringPriceEvent shadows an event's price fields so they marshal as strings such as "120.00"
+type stringPriceEvent struct {
+	TicketEvent
+	PriceValue string `json:"price_value,omitempty"`
+	PriceMin   string `json:"price_min,omitempty"`
+	PriceMax   string `json:"price_max,omitempty"`
+}
+
+// stringPriceResult is a ScrapingResult whose events marshal their prices as strings
+type stringPriceResult struct {
+	Events []stringPriceEvent `json:"events"` // First, to keep the usual field order
+	*ScrapingResult
+}
+
+// priceString formats a price as a string, leaving unset prices empty so they are omitted
+func priceString(p Price) string {
+	if p == 0 {
+		return ""
+	}
+	return p.String()
+}
+
+// FormatAsJSONWithStringPrices formats the results as JSON like FormatAsJSON,
+// but with prices as strings such as "120.00" for clients that display them verbatim
+func (r *ScrapingResult) FormatAsJSONWithStringPrices(indent bool) (string, error) {
+	wrapped := stringPriceResult{ScrapingResult: r, Events: make([]stringPriceEvent, len(r.Events))}
+	for i, event := range r.Events {
+		wrapped.Events[i] = stringPriceEvent{
+			TicketEvent: event,
+			PriceValue:  priceString(event.PriceValue),
+			PriceMin:    priceString(event.PriceMin),
+			PriceMax:    priceString(event.PriceMax),
+		}
+	}
+
+	var data []byte
+	var err error
+	if indent {
+		data, err = json.MarshalIndent(wrapped, "", "  ")
+	} else {
+		data, err = json.Marshal(wrapped)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// priceNumberPattern matches the amount in price text such as "From $120", "€1.234,50" or "99.99 EUR"
+var priceNumberPattern = regexp.MustCompile(`\d[\d.,]*`)
+
+// currencySymbols maps price symbols and codes to ISO currency codes. Longer
+// symbols come first, so "CA$" isn't read as "$" or "A$".
+var currencySymbols = []struct {
+	symbol string
+	code   string
+}{
+	{"CA$", "CAD"},
+	{"US$", "USD"},
+	{"EUR", "EUR"},
+	{"USD", "USD"},
+	{"GBP", "GBP"},
+	{"CAD", "CAD"},
+	{"AUD", "AUD"},
+	{"A$", "AUD"},
+	{"€", "EUR"},
+	{"$", "USD"},
+	{"£", "GBP"},
+}
+
+// parsePrice extracts the amount and currency from listing price text. It
+// returns ok=false when the text has no amount.
+func parsePrice(text string) (amount float64, currency string, ok bool) {
+	number := priceNumberPattern.FindString(text)
+	if number == "" {
+		return 0, "", false
+	}
+
+	amount, err := strconv.ParseFloat(normalizeDecimal(number), 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	upper := strings.ToUpper(text)
+	for _, c := range currencySymbols {
+		if strings.Contains(upper, c.symbol) {
+			currency = c.code
+			break
+		}
+	}
+
+	return amount, currency, true
+}
+
+// normalizeDecimal turns "1.234,50", "1,234.50" and "1,234" into a Go-parseable number.
+// The last separator is the decimal point only when followed by one or two digits.
+func normalizeDecimal(number string) string {
+	number = strings.TrimRight(number, ".,")
+	last := strings.LastIndexAny(number, ".,")
+	if last < 0 {
+		return number
+	}
+
+	intPart := strings.NewReplacer(".", "", ",", "").Replace(number[:last])
+	fraction := number[last+1:]
+	if len(fraction) <= 2 {
+		return intPart + "." + fraction
+	}
+	return intPart + fraction
+}
@@ -0,0 +1,94 @@
+package scraper
+
+import "sort"
+
+// PriceStats summarizes the prices listed in one currency
+type PriceStats struct {
+	Count    int    `json:"count"`
+	Min      Price  `json:"min"`
+	Max      Price  `json:"max"`
+	Avg      Price  `json:"avg"`
+	Median   Price  `json:"median"`
+	Currency string `json:"currency"`
+}
+
+// PriceReport summarizes the prices of a result. There is no currency
+// converter, so mixed currencies are reported one entry per currency.
+type PriceReport struct {
+	*PriceStats              // Set when every priced event shares one currency
+	ByCurrency  []PriceStats `json:"by_currency,omitempty"` // Set instead when currencies are mixed
+	Unpriced    int          `json:"unpriced"`              // Events without a parsed price, excluded from the stats
+}
+
+// WithPriceOnly drops events without a parsed price, such as Sport365 fixtures
+// and listings that show no price
+func (r *ScrapingResult) WithPriceOnly() *ScrapingResult {
+	filtered := r.withEvents([]TicketEvent{})
+
+	for _, event := range r.Events {
+		if event.PriceValue > 0 {
+			filtered.Events = append(filtered.Events, event)
+		}
+	}
+
+	filtered.Total = len(filtered.Events)
+	return filtered
+}
+
+// PriceStatistics computes count, min, max, average and median over the events with a parsed price
+func (r *ScrapingResult) PriceStatistics() PriceReport {
+	var report PriceReport
+	byCurrency := make(map[string][]float64)
+	var currencies []string
+
+	for _, event := range r.Events {
+		if event.PriceValue <= 0 {
+			report.Unpriced++
+			continue
+		}
+		if _, seen := byCurrency[event.Currency]; !seen {
+			currencies = append(currencies, event.Currency)
+		}
+		byCurrency[event.Currency] = append(byCurrency[event.Currency], float64(event.PriceValue))
+	}
+
+	switch len(currencies) {
+	case 0:
+		report.PriceStats = &PriceStats{}
+	case 1:
+		stats := priceStats(currencies[0], byCurrency[currencies[0]])
+		report.PriceStats = &stats
+	default:
+		sort.Strings(currencies)
+		for _, currency := range currencies {
+			report.ByCurrency = append(report.ByCurrency, priceStats(currency, byCurrency[currency]))
+		}
+	}
+
+	return report
+}
+
+// priceStats summarizes a non-empty list of prices in one currency
+func priceStats(currency string, prices []float64) PriceStats {
+	sort.Float64s(prices)
+
+	sum := 0.0
+	for _, price := range prices {
+		sum += price
+	}
+
+	n := len(prices)
+	median := prices[n/2]
+	if n%2 == 0 {
+		median = (prices[n/2-1] + prices[n/2]) / 2
+	}
+
+	return PriceStats{
+		Count:    n,
+		Min:      Price(prices[0]),
+		Max:      Price(prices[n-1]),
+		Avg:      Price(sum / float64(n)),
+		Median:   Price(median),
+		Currency: currency,
+	}
+}
@@ -0,0 +1,84 @@
+package scraper
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		text     string
+		amount   float64
+		currency string
+		ok       bool
+	}{
+		{"From $120", 120, "USD", true},
+		{"From US$120", 120, "USD", true},
+		{"CA$89.50", 89.5, "CAD", true},
+		{"A$75", 75, "AUD", true},
+		{"€1.234,50", 1234.5, "EUR", true},
+		{"1,234.50 eur", 1234.5, "EUR", true},
+		{"£45", 45, "GBP", true},
+		{"99.99", 99.99, "", true},
+		{"Sold out", 0, "", false},
+	}
+
+	for _, tc := range tests {
+		// Repeat, as map iteration once made the currency vary between runs
+		for range 20 {
+			amount, currency, ok := parsePrice(tc.text)
+			if amount != tc.amount || currency != tc.currency || ok != tc.ok {
+				t.Fatalf("parsePrice(%q) = %v, %q, %v; want %v, %q, %v", tc.text, amount, currency, ok, tc.amount, tc.currency, tc.ok)
+			}
+		}
+	}
+}
+
+func TestPriceMarshalJSON(t *testing.T) {
+	tests := []struct {
+		price Price
+		want  string
+	}{
+		{120, "120.00"},
+		{99.99, "99.99"},
+		{99.985, "99.99"},
+		{99.989999, "99.99"},
+		{0.5, "0.50"},
+	}
+
+	for _, tc := range tests {
+		data, err := json.Marshal(tc.price)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tc.want {
+			t.Errorf("json.Marshal(%v) = %s, want %s", float64(tc.price), data, tc.want)
+		}
+	}
+}
+
+func TestFormatAsJSONWithStringPrices(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{{Event: "Real Madrid vs Getafe", PriceValue: 120, PriceMin: 99.985}}}
+
+	out, err := result.FormatAsJSONWithStringPrices(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Events []map[string]any `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	event := decoded.Events[0]
+	if event["price_value"] != "120.00" || event["price_min"] != "99.99" {
+		t.Errorf("got price_value %#v, price_min %#v; want \"120.00\", \"99.99\"", event["price_value"], event["price_min"])
+	}
+	if _, ok := event["price_max"]; ok {
+		t.Errorf("unset price_max was written as %#v", event["price_max"])
+	}
+	if event["event"] != "Real Madrid vs Getafe" {
+		t.Errorf("event = %#v", event["event"])
+	}
+}
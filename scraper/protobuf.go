@@ -0,0 +1,173 @@
+package scraper
+
+//go:generate protoc --go_out=.. --go_opt=module=normalizer scraping_result.proto
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"normalizer/scraper/pb"
+)
+
+// FormatAsProtobuf encodes the result as a ScrapingResult message of
+// scraping_result.proto, for consumers that want binary protobuf over JSON
+func (r *ScrapingResult) FormatAsProtobuf() ([]byte, error) {
+	return proto.Marshal(r.toProto())
+}
+
+// ParseProtobuf decodes a ScrapingResult message written by FormatAsProtobuf
+func ParseProtobuf(data []byte) (*ScrapingResult, error) {
+	var message pb.ScrapingResult
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return nil, fmt.Errorf("invalid ScrapingResult message: %w", err)
+	}
+	return fromProto(&message), nil
+}
+
+// toProto maps the result onto the generated ScrapingResult message
+func (r *ScrapingResult) toProto() *pb.ScrapingResult {
+	message := &pb.ScrapingResult{
+		Total:             int32(r.Total),
+		Timestamp:         toTimestamp(r.Timestamp),
+		SourceUrl:         r.SourceURL,
+		Source:            r.Source,
+		SourceStatus:      r.SourceStatus,
+		RawTotal:          int32(r.RawTotal),
+		DuplicatesRemoved: int32(r.DuplicatesRemoved),
+		Partial:           r.Partial,
+		ServedBy:          r.ServedBy,
+		SourceUrls:        r.SourceURLs,
+	}
+	for _, event := range r.Events {
+		message.Events = append(message.Events, eventToProto(event))
+	}
+	for _, scrapeErr := range r.Errors {
+		message.Errors = append(message.Errors, &pb.ScrapeError{
+			Source: scrapeErr.Source,
+			Url:    scrapeErr.URL,
+			Kind:   string(scrapeErr.Kind),
+		})
+	}
+	return message
+}
+
+// fromProto maps a generated ScrapingResult message back onto the result
+func fromProto(message *pb.ScrapingResult) *ScrapingResult {
+	r := &ScrapingResult{
+		Events:            []TicketEvent{},
+		Total:             int(message.GetTotal()),
+		Timestamp:         fromTimestamp(message.GetTimestamp()),
+		SourceURL:         message.GetSourceUrl(),
+		Source:            message.GetSource(),
+		SourceStatus:      message.GetSourceStatus(),
+		RawTotal:          int(message.GetRawTotal()),
+		DuplicatesRemoved: int(message.GetDuplicatesRemoved()),
+		Partial:           message.GetPartial(),
+		ServedBy:          message.GetServedBy(),
+		SourceURLs:        message.GetSourceUrls(),
+	}
+	for _, event := range message.GetEvents() {
+		r.Events = append(r.Events, eventFromProto(event))
+	}
+	for _, scrapeErr := range message.GetErrors() {
+		r.Errors = append(r.Errors, ScrapeError{
+			Source: scrapeErr.GetSource(),
+			URL:    scrapeErr.GetUrl(),
+			Kind:   ErrorKind(scrapeErr.GetKind()),
+		})
+	}
+	return r
+}
+
+// eventToProto maps an event onto the generated TicketEvent message
+func eventToProto(e TicketEvent) *pb.TicketEvent {
+	return &pb.TicketEvent{
+		Datetime:             e.DateTime,
+		Event:                e.Event,
+		Link:                 e.Link,
+		Source:               e.Source,
+		HomeTeam:             e.HomeTeam,
+		AwayTeam:             e.AwayTeam,
+		Round:                e.Round,
+		Competition:          e.Competition,
+		OriginalIndex:        int32(e.OriginalIndex),
+		ImageUrl:             e.ImageURL,
+		OriginalLink:         e.OriginalLink,
+		Type:                 e.Type,
+		Status:               e.Status,
+		Multi:                e.Multi,
+		DoorsTime:            e.DoorsTime,
+		DateUnparsed:         e.DateUnparsed,
+		Past:                 e.Past,
+		Venue:                e.Venue,
+		Availability:         e.Availability,
+		Price:                e.Price,
+		PriceValue:           float64(e.PriceValue),
+		PriceMin:             float64(e.PriceMin),
+		PriceMax:             float64(e.PriceMax),
+		Currency:             e.Currency,
+		ListingCount:         int32(e.ListingCount),
+		NormalizedConfidence: e.NormalizedConfidence,
+		ScrapedAt:            toTimestamp(e.ScrapedAt),
+		Meta:                 e.Meta,
+		RawHtml:              e.RawHTML,
+		StartDate:            e.StartDate,
+		EndDate:              e.EndDate,
+	}
+}
+
+// eventFromProto maps a generated TicketEvent message back onto an event
+func eventFromProto(message *pb.TicketEvent) TicketEvent {
+	return TicketEvent{
+		DateTime:             message.GetDatetime(),
+		Event:                message.GetEvent(),
+		Link:                 message.GetLink(),
+		Source:               message.GetSource(),
+		HomeTeam:             message.GetHomeTeam(),
+		AwayTeam:             message.GetAwayTeam(),
+		Round:                message.GetRound(),
+		Competition:          message.GetCompetition(),
+		OriginalIndex:        int(message.GetOriginalIndex()),
+		ImageURL:             message.GetImageUrl(),
+		OriginalLink:         message.GetOriginalLink(),
+		Type:                 message.GetType(),
+		Status:               message.GetStatus(),
+		Multi:                message.GetMulti(),
+		DoorsTime:            message.GetDoorsTime(),
+		DateUnparsed:         message.GetDateUnparsed(),
+		Past:                 message.GetPast(),
+		Venue:                message.GetVenue(),
+		Availability:         message.GetAvailability(),
+		Price:                message.GetPrice(),
+		PriceValue:           Price(message.GetPriceValue()),
+		PriceMin:             Price(message.GetPriceMin()),
+		PriceMax:             Price(message.GetPriceMax()),
+		Currency:             message.GetCurrency(),
+		ListingCount:         int(message.GetListingCount()),
+		NormalizedConfidence: message.GetNormalizedConfidence(),
+		ScrapedAt:            fromTimestamp(message.GetScrapedAt()),
+		Meta:                 message.GetMeta(),
+		RawHTML:              message.GetRawHtml(),
+		StartDate:            message.GetStartDate(),
+		EndDate:              message.GetEndDate(),
+	}
+}
+
+// toTimestamp converts t to a google.protobuf.Timestamp, leaving out the zero time
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// fromTimestamp converts a google.protobuf.Timestamp back to UTC, keeping a missing one zero
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
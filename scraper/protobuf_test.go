@@ -0,0 +1,82 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProtobufRoundTrip(t *testing.T) {
+	scrapedAt := time.Date(2026, 3, 14, 18, 30, 5, 250, time.UTC)
+	want := &ScrapingResult{
+		Events: []TicketEvent{
+			{
+				DateTime:             "2026-03-21 20:00",
+				Event:                "Real Betis vs Sevilla FC",
+				Link:                 "https://example.com/betis-sevilla",
+				Source:               "vividseats",
+				HomeTeam:             "Real Betis",
+				AwayTeam:             "Sevilla FC",
+				Round:                "Jornada 28",
+				Competition:          "LaLiga",
+				OriginalIndex:        3,
+				ImageURL:             "https://example.com/betis.png",
+				OriginalLink:         "https://example.com/betis-sevilla?ref=list",
+				Type:                 "match",
+				Status:               "on_sale",
+				DoorsTime:            "18:30",
+				Past:                 true,
+				Venue:                "Estadio Benito Villamarín",
+				Availability:         "limited",
+				Price:                "€45.50",
+				PriceValue:           45.5,
+				PriceMin:             45.5,
+				PriceMax:             120,
+				Currency:             "EUR",
+				ListingCount:         4,
+				NormalizedConfidence: 0.92,
+				ScrapedAt:            scrapedAt,
+				Meta:                 map[string]string{"performer_id": "1234"},
+				RawHTML:              "<div>Real Betis vs Sevilla FC</div>",
+			},
+			{
+				DateTime:     "March 2026",
+				Event:        "Copa del Rey tickets",
+				Source:       "hellotickets",
+				Multi:        true,
+				DateUnparsed: true,
+				StartDate:    "2026-03-01",
+				EndDate:      "2026-03-31",
+			},
+		},
+		Total:             2,
+		Timestamp:         scrapedAt.Add(time.Minute),
+		SourceURL:         "https://example.com/laliga",
+		Source:            "combined",
+		SourceStatus:      map[string]string{"vividseats": "ok", "hellotickets": "ok"},
+		RawTotal:          5,
+		DuplicatesRemoved: 3,
+		Errors:            []ScrapeError{{Source: "stubhub", URL: "https://example.com/stubhub", Kind: ErrorKindTimeout}},
+		Partial:           true,
+		ServedBy:          "cache",
+		SourceURLs:        map[string]string{"vividseats": "https://example.com/laliga"},
+	}
+
+	data, err := want.FormatAsProtobuf()
+	if err != nil {
+		t.Fatalf("FormatAsProtobuf() error = %v", err)
+	}
+	got, err := ParseProtobuf(data)
+	if err != nil {
+		t.Fatalf("ParseProtobuf() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseProtobufRejectsGarbage(t *testing.T) {
+	if _, err := ParseProtobuf([]byte{0x0a, 0xff}); err == nil {
+		t.Error("ParseProtobuf() of a truncated message succeeded, want an error")
+	}
+}
@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress is returned when a scrape of an arbitrary site targets a
+// host on a private, loopback or link-local address, such as a cloud metadata
+// endpoint or a service on the server's own network
+var ErrNonPublicAddress = errors.New("non-public address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which isn't reachable from the internet either
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublicIP reports whether ip is a unicast address routed on the public internet
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// checkPublicHost resolves host and fails with ErrNonPublicAddress unless every
+// address it resolves to is public
+func checkPublicHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !isPublicIP(ip) {
+			return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrNonPublicAddress, host, addr.IP)
+		}
+	}
+	return nil
+}
+
+// publicOnlyTransport returns a transport that refuses to connect to non-public
+// addresses. The check runs on the address actually dialled, so a host that
+// resolves differently after checkPublicHost, or a redirect, can't get around it.
+// Proxies aren't used, as the transport would then only see the proxy's address.
+func publicOnlyTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
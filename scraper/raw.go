@@ -0,0 +1,19 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// rawHTML returns the outer HTML of a listing element for auditing, or "" when capture is off
+func rawHTML(sel *goquery.Selection, capture bool) string {
+	if !capture {
+		return ""
+	}
+	html, err := goquery.OuterHtml(sel)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(html)
+}
@@ -0,0 +1,47 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrUnexpectedRedirect is returned when a site redirects a scrape to a page
+// that can't hold listings, such as a login or region chooser
+var ErrUnexpectedRedirect = errors.New("unexpected redirect")
+
+// defaultMaxRedirects matches the limit colly and net/http apply by default
+const defaultMaxRedirects = 10
+
+// redirectHandler returns a colly redirect handler that follows up to
+// maxRedirects redirects and then keeps the last response, as colly does by
+// default. A redirect to a path starting with one of the unexpected prefixes
+// fails the request with ErrUnexpectedRedirect.
+func redirectHandler(maxRedirects int, unexpected []string) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		for _, prefix := range unexpected {
+			if strings.HasPrefix(req.URL.Path, prefix) {
+				return fmt.Errorf("%w to %s", ErrUnexpectedRedirect, req.URL)
+			}
+		}
+
+		if len(via) >= maxRedirects {
+			return http.ErrUseLastResponse
+		}
+
+		// Like colly, don't leak credentials to another host
+		if last := via[len(via)-1]; req.URL.Host != last.URL.Host {
+			req.Header.Del("Authorization")
+		}
+		return nil
+	}
+}
+
+// maxRedirects returns the configured redirect limit, falling back to defaultMaxRedirects when unset
+func (o ScraperOptions) maxRedirects() int {
+	if o.MaxRedirects == nil {
+		return defaultMaxRedirects
+	}
+	return *o.MaxRedirects
+}
@@ -0,0 +1,73 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// regionSelectSite redirects listing pages to a region chooser, then serves
+// one listing from any other page
+func regionSelectSite(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.Contains(r.URL.Path, "/performer/"):
+		http.Redirect(w, r, "/region-select?next="+r.URL.Path, http.StatusFound)
+	default:
+		w.Write([]byte(vividPage("",
+			vividListing("/real-madrid-vs-getafe/production/1", "Real Madrid vs Getafe", "Sep 2", "Sat", "9:00pm"),
+		)))
+	}
+}
+
+func TestUnexpectedRedirectIsReported(t *testing.T) {
+	opts := testOptions()
+	opts.VividSeatsPerformers = []string{"11111"}
+	opts.UnexpectedRedirects = []string{"/region-select"}
+	s := NewVividSeatsScraperWithOptions(opts)
+	mockSite(t, s.collector, http.HandlerFunc(regionSelectSite))
+
+	result, err := s.Scrape(context.Background())
+	if !errors.Is(err, ErrUnexpectedRedirect) {
+		t.Fatalf("got %+v, %v; want ErrUnexpectedRedirect", result, err)
+	}
+	var scrapeErr *ScrapeError
+	if !errors.As(err, &scrapeErr) || scrapeErr.Kind != ErrorKindBlocked {
+		t.Errorf("error %v is not a blocked ScrapeError", err)
+	}
+}
+
+func TestMaxRedirects(t *testing.T) {
+	zero := 0
+	for _, tc := range []struct {
+		name         string
+		maxRedirects *int
+		followed     bool
+	}{
+		{"default follows", nil, true},
+		{"zero stops at the first redirect", &zero, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := testOptions()
+			opts.VividSeatsPerformers = []string{"11111"}
+			opts.MaxRedirects = tc.maxRedirects
+			s := NewVividSeatsScraperWithOptions(opts)
+			followed := false
+			mockSite(t, s.collector, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/region-select") {
+					followed = true
+				}
+				regionSelectSite(w, r)
+			}))
+
+			result, err := s.Scrape(context.Background())
+			if followed != tc.followed {
+				t.Errorf("followed the redirect: %v, want %v", followed, tc.followed)
+			}
+			if tc.followed && (err != nil || result.Total != 1) {
+				t.Errorf("got %+v, %v; want the redirected page's event", result, err)
+			}
+		})
+	}
+}
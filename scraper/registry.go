@@ -0,0 +1,148 @@
+package scraper
+
+import (
+	"slices"
+	"sync"
+)
+
+// SourceFactory builds a source's scraper from the options configured for it
+type SourceFactory func(opts ScraperOptions) SourceScraper
+
+// registration is a registered source: what it is and how to build its scraper
+type registration struct {
+	info    SourceInfo
+	factory SourceFactory
+}
+
+// Registry maps source names to their descriptions and the factories building
+// their scrapers, so a new site is added by registering it rather than by
+// editing the server. Register takes a full SourceInfo and a factory given the
+// source's options, rather than just a name and a scraper constructor, since
+// /sources, credentials and URL checks need the description and scrapers need
+// the configured options; RegisterScraper covers the name-only case.
+type Registry struct {
+	mu      sync.RWMutex
+	sources map[string]registration
+	names   []string // In registration order
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{sources: make(map[string]registration)}
+}
+
+// Register adds the source named by info.Name, replacing one already registered
+// under that name. info's capabilities are what GET /sources reports, so they
+// should match the fields the factory's scraper fills in.
+func (r *Registry) Register(info SourceInfo, factory SourceFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.sources[info.Name]; !exists {
+		r.names = append(r.names, info.Name)
+	}
+	r.sources[info.Name] = registration{info: info, factory: factory}
+}
+
+// RegisterScraper adds a source known only by its name, whose scraper ignores
+// the configured options. It reports no capabilities and has no domain, so
+// /event and credentials are refused for it; use Register to describe it.
+func (r *Registry) RegisterScraper(name string, factory func() SourceScraper) {
+	r.Register(SourceInfo{Name: name, DisplayName: name}, func(ScraperOptions) SourceScraper { return factory() })
+}
+
+// Unregister removes the named source, e.g. a built-in one that shouldn't be scraped
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.sources[name]; !exists {
+		return
+	}
+	delete(r.sources, name)
+	r.names = slices.DeleteFunc(r.names, func(registered string) bool { return registered == name })
+}
+
+// Get returns the named source's factory, reporting false for an unknown source
+func (r *Registry) Get(name string) (SourceFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	source, ok := r.sources[name]
+	return source.factory, ok
+}
+
+// Info returns the named source's description, reporting false for an unknown source
+func (r *Registry) Info(name string) (SourceInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	source, ok := r.sources[name]
+	return source.info, ok
+}
+
+// Infos returns the descriptions of the registered sources in registration order
+func (r *Registry) Infos() []SourceInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	infos := make([]SourceInfo, len(r.names))
+	for i, name := range r.names {
+		infos[i] = r.sources[name].info
+	}
+	return infos
+}
+
+// Names returns the registered source names in registration order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.names)
+}
+
+// DefaultRegistry holds the sources the server scrapes, starting with the built-in ones
+var DefaultRegistry = NewRegistry()
+
+// The built-ins are registered once the package is initialised, as their
+// scrapers look their domains up in DefaultRegistry
+func init() {
+	registerBuiltIns(DefaultRegistry)
+}
+
+// registerBuiltIns registers the built-in sources in r
+func registerBuiltIns(r *Registry) {
+	r.Register(
+		SourceInfo{Name: "hellotickets", DisplayName: "HelloTickets", Domain: "hellotickets.com", HasPrice: true, HasTime: true, HasCompetition: true, Cookies: true, BasicAuth: true},
+		func(opts ScraperOptions) SourceScraper { return NewScraperWithOptions(opts) },
+	)
+	r.Register(
+		SourceInfo{Name: "vividseats", DisplayName: "VividSeats", Domain: "vividseats.com", HasPrice: true, HasTime: true, HasCompetition: true, Cookies: true, BasicAuth: true},
+		func(opts ScraperOptions) SourceScraper { return NewVividSeatsScraperWithOptions(opts) },
+	)
+	r.Register(
+		// Chrome would send basic auth to every site the page loads from, so only cookies
+		SourceInfo{Name: "sport365", DisplayName: "Sport365", Domain: "sport365.com", HasCompetition: true, UsesBrowser: true, Cookies: true},
+		func(opts ScraperOptions) SourceScraper { return NewSport365ScraperWithOptions(opts) },
+	)
+}
+
+// NewSourceScraper builds the named source's scraper from DefaultRegistry,
+// reporting false for an unknown source
+func NewSourceScraper(name string, opts ScraperOptions) (SourceScraper, bool) {
+	factory, ok := DefaultRegistry.Get(name)
+	if !ok {
+		return nil, false
+	}
+	return factory(opts), true
+}
+
+// IsSource reports whether name is registered in DefaultRegistry
+func IsSource(name string) bool {
+	_, ok := DefaultRegistry.Get(name)
+	return ok
+}
+
+// SourceNames returns the names of the sources in DefaultRegistry, in registration order
+func SourceNames() []string {
+	return DefaultRegistry.Names()
+}
+
+// SourceInfos returns the descriptions of the sources in DefaultRegistry, in registration order
+func SourceInfos() []SourceInfo {
+	return DefaultRegistry.Infos()
+}
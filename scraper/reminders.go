@@ -0,0 +1,58 @@
+package scraper
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FormatAsReminders formats the events as a plain-text schedule for pasting into
+// a notes or reminders app: one "DATE — EVENT — LINK" line per event, in date
+// order under a header per month. Events without a readable date are listed
+// last under "Undated", in their original order.
+func (r *ScrapingResult) FormatAsReminders() string {
+	type dated struct {
+		event TicketEvent
+		date  time.Time
+	}
+	var events []dated
+	var undated []TicketEvent
+	for _, event := range r.Events {
+		date, err := parseEventDate(event.DateTime)
+		if err != nil {
+			undated = append(undated, event)
+			continue
+		}
+		events = append(events, dated{event, date})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].date.Before(events[j].date)
+	})
+
+	var sb strings.Builder
+	month := ""
+	for _, e := range events {
+		if header := e.date.Format("January 2006"); header != month {
+			if month != "" {
+				sb.WriteString("\n")
+			}
+			month = header
+			fmt.Fprintf(&sb, "%s\n", month)
+		}
+		fmt.Fprintf(&sb, "%s — %s — %s\n", e.event.DateTime, e.event.Event, e.event.Link)
+	}
+
+	if len(undated) > 0 {
+		if month != "" {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("Undated\n")
+		for _, event := range undated {
+			fmt.Fprintf(&sb, "%s — %s — %s\n", cmp.Or(event.DateTime, "TBD"), event.Event, event.Link)
+		}
+	}
+
+	return sb.String()
+}
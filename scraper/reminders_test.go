@@ -0,0 +1,37 @@
+package scraper
+
+import "testing"
+
+func TestFormatAsRemindersGroupsByMonth(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{
+		{DateTime: "15 Jan 2027", Event: "Real Madrid vs Sevilla", Link: "https://example.com/3"},
+		{DateTime: "TBC", Event: "Copa del Rey tickets", Link: "https://example.com/5"},
+		{DateTime: "20 Dec 2026", Event: "Real Madrid vs Betis", Link: "https://example.com/2"},
+		{DateTime: "06 Dec 2026", Event: "Real Madrid vs Getafe", Link: "https://example.com/1"},
+		{Event: "Stadium tour", Link: "https://example.com/6"},
+		{DateTime: "02 Jan 2027", Event: "Real Madrid vs Alaves", Link: "https://example.com/4"},
+	}}
+
+	want := "December 2026\n" +
+		"06 Dec 2026 — Real Madrid vs Getafe — https://example.com/1\n" +
+		"20 Dec 2026 — Real Madrid vs Betis — https://example.com/2\n" +
+		"\n" +
+		"January 2027\n" +
+		"02 Jan 2027 — Real Madrid vs Alaves — https://example.com/4\n" +
+		"15 Jan 2027 — Real Madrid vs Sevilla — https://example.com/3\n" +
+		"\n" +
+		"Undated\n" +
+		"TBC — Copa del Rey tickets — https://example.com/5\n" +
+		"TBD — Stadium tour — https://example.com/6\n"
+	if got := result.FormatAsReminders(); got != want {
+		t.Errorf("FormatAsReminders() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatAsRemindersOnlyUndated(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{{DateTime: "TBC", Event: "Copa del Rey tickets", Link: "https://example.com/1"}}}
+	want := "Undated\nTBC — Copa del Rey tickets — https://example.com/1\n"
+	if got := result.FormatAsReminders(); got != want {
+		t.Errorf("FormatAsReminders() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+package scraper
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// emptyRetryDelay is how long to wait before retrying a scrape that parsed no events
+const emptyRetryDelay = 2 * time.Second
+
+// retryEmptyScrape visits url once more after a short delay, for sites whose
+// edge cache sometimes serves a page without listings on the first hit. The
+// collector's callbacks add to the same result, so count reports how many
+// events it holds after the retry. Failures are logged, keeping the empty result.
+func retryEmptyScrape(ctx context.Context, c *colly.Collector, source, url string, count func() int) {
+	log.Printf("%s: no events parsed from %s, retrying once in %v", source, url, emptyRetryDelay)
+
+	timer := time.NewTimer(emptyRetryDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		log.Printf("%s: retry cancelled: %v", source, ctx.Err())
+		return
+	}
+
+	// The first attempt marked the URL as visited
+	c.AllowURLRevisit = true
+	if err := c.Visit(url); err != nil {
+		log.Printf("%s: retry failed: %v", source, err)
+		return
+	}
+
+	if n := count(); n > 0 {
+		log.Printf("%s: retry found %d events", source, n)
+	} else {
+		log.Printf("%s: retry also found no events", source)
+	}
+}
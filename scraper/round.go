@@ -0,0 +1,34 @@
+package scraper
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// roundPattern matches competition round labels such as "Matchday 12" or "Round of 16"
+var roundPattern = regexp.MustCompile(`(?i)\b(matchday\s+\d+|jornada\s+\d+|round\s+of\s+\d+|\d+(st|nd|rd|th)\s+round|group\s+stage|quarter-?finals?|semi-?finals?|play-?offs?)\b`)
+
+// extractRound returns the first round label found in text, or "" if there is none
+func extractRound(text string) string {
+	match := roundPattern.FindString(text)
+	if match == "" {
+		return ""
+	}
+	// Collapse internal whitespace picked up from the DOM
+	return strings.Join(strings.Fields(match), " ")
+}
+
+// IncludeRoundInName appends the round to each event name, e.g. "A vs B (Matchday 12)"
+func (r *ScrapingResult) IncludeRoundInName() *ScrapingResult {
+	withRound := r.withEvents(make([]TicketEvent, len(r.Events)))
+
+	for i, event := range r.Events {
+		if event.Round != "" && !strings.Contains(event.Event, event.Round) {
+			event.Event = fmt.Sprintf("%s (%s)", event.Event, event.Round)
+		}
+		withRound.Events[i] = event
+	}
+
+	return withRound
+}
@@ -0,0 +1,24 @@
+package scraper
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Sample keeps n events picked at random with a generator seeded by seed, so
+// the same seed always picks the same events. The picked events keep their
+// order in r; when r has no more than n events it is returned as is.
+func (r *ScrapingResult) Sample(n int, seed int64) *ScrapingResult {
+	if n < 0 || n >= len(r.Events) {
+		return r
+	}
+
+	picked := rand.New(rand.NewSource(seed)).Perm(len(r.Events))[:n]
+	sort.Ints(picked)
+
+	events := make([]TicketEvent, 0, n)
+	for _, i := range picked {
+		events = append(events, r.Events[i])
+	}
+	return r.withEvents(events)
+}
@@ -0,0 +1,44 @@
+package scraper
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSampleIsReproducible(t *testing.T) {
+	result := &ScrapingResult{Source: "all", SourceURL: "https://example.com", Timestamp: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	for i := range 50 {
+		result.Events = append(result.Events, TicketEvent{Event: fmt.Sprintf("Match %02d", i)})
+	}
+	result.Total = len(result.Events)
+
+	first := result.Sample(10, 42)
+	if got := eventNames(first); !slices.Equal(got, eventNames(result.Sample(10, 42))) {
+		t.Errorf("seed 42 picked different events on the second run")
+	}
+	if slices.Equal(eventNames(first), eventNames(result.Sample(10, 7))) {
+		t.Errorf("seeds 42 and 7 picked the same events")
+	}
+
+	if first.Total != 10 || len(first.Events) != 10 {
+		t.Errorf("sample has %d events (Total %d), want 10", len(first.Events), first.Total)
+	}
+	if !slices.IsSorted(eventNames(first)) {
+		t.Errorf("sampled events %v lost their original order", eventNames(first))
+	}
+	if first.Source != result.Source || first.SourceURL != result.SourceURL || !first.Timestamp.Equal(result.Timestamp) {
+		t.Errorf("sample lost the result's metadata: %+v", first)
+	}
+	if result.Total != 50 {
+		t.Errorf("sampling changed the original result's Total to %d", result.Total)
+	}
+}
+
+func TestSampleLargerThanResult(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{{Event: "A"}, {Event: "B"}}, Total: 2}
+	if got := result.Sample(5, 1); got != result {
+		t.Errorf("Sample(5) of 2 events = %+v, want the result unchanged", got)
+	}
+}
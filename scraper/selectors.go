@@ -0,0 +1,106 @@
+package scraper
+
+import (
+	"fmt"
+
+	"github.com/andybalholm/cascadia"
+)
+
+// Selectors holds the CSS selectors a scraper uses to find listings and their
+// fields. Empty fields fall back to the source's built-in defaults.
+type Selectors struct {
+	Listing  string `json:"listing_selector,omitempty"`
+	Link     string `json:"link_selector,omitempty"`
+	Event    string `json:"event_selector,omitempty"`
+	Date     string `json:"date_selector,omitempty"`
+	Day      string `json:"day_selector,omitempty"`
+	Time     string `json:"time_selector,omitempty"`
+	HomeTeam string `json:"home_team_selector,omitempty"`
+	AwayTeam string `json:"away_team_selector,omitempty"`
+	Price    string `json:"price_selector,omitempty"`
+}
+
+// DefaultHelloTicketsSelectors returns the built-in HelloTickets selectors
+func DefaultHelloTicketsSelectors() Selectors {
+	return Selectors{
+		Listing: "li.performance.performances-list__item",
+		Link:    "a.performance__link",
+		Event:   ".performance__description__name",
+		Date:    ".performance__date-month",
+		Day:     ".performance__date-day p:first-child",
+		Time:    ".performance__date-day p:last-child",
+		Price:   ".performance__price",
+	}
+}
+
+// DefaultVividSeatsSelectors returns the built-in VividSeats selectors
+func DefaultVividSeatsSelectors() Selectors {
+	return Selectors{
+		Listing: "div[data-testid*='production-listing']",
+		Link:    "a.styles_linkContainer__4li3j",
+		Event:   "span.styles_titleTruncate__XiZ53",
+		Date:    "div[data-testid='date-time-left-element'] span.MuiTypography-small-bold",
+		Day:     "div[data-testid='date-time-left-element'] span.MuiTypography-overline",
+		Time:    "div[data-testid='date-time-left-element'] span.MuiTypography-caption",
+		Price:   "[data-testid='listing-price']",
+	}
+}
+
+// DefaultSport365Selectors returns the built-in Sport365 selectors. The match
+// row itself is the link, so Link is unused.
+func DefaultSport365Selectors() Selectors {
+	return Selectors{
+		Listing:  "a.match-row",
+		Date:     ".match-col.status .status-content",
+		HomeTeam: ".match-col.home-team .team-name",
+		AwayTeam: ".match-col.away-team .team-name",
+	}
+}
+
+// withDefaults fills every empty selector from defaults
+func (s Selectors) withDefaults(defaults Selectors) Selectors {
+	fill := func(value, fallback string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	}
+
+	return Selectors{
+		Listing:  fill(s.Listing, defaults.Listing),
+		Link:     fill(s.Link, defaults.Link),
+		Event:    fill(s.Event, defaults.Event),
+		Date:     fill(s.Date, defaults.Date),
+		Day:      fill(s.Day, defaults.Day),
+		Time:     fill(s.Time, defaults.Time),
+		HomeTeam: fill(s.HomeTeam, defaults.HomeTeam),
+		AwayTeam: fill(s.AwayTeam, defaults.AwayTeam),
+		Price:    fill(s.Price, defaults.Price),
+	}
+}
+
+// Validate checks that every provided selector is valid CSS
+func (s Selectors) Validate() error {
+	fields := map[string]string{
+		"listing_selector":   s.Listing,
+		"link_selector":      s.Link,
+		"event_selector":     s.Event,
+		"date_selector":      s.Date,
+		"day_selector":       s.Day,
+		"time_selector":      s.Time,
+		"home_team_selector": s.HomeTeam,
+		"away_team_selector": s.AwayTeam,
+		"price_selector":     s.Price,
+	}
+
+	for name, selector := range fields {
+		if selector == "" {
+			continue
+		}
+		if _, err := cascadia.ParseGroup(selector); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", name, selector, err)
+		}
+	}
+
+	return nil
+}
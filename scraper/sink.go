@@ -0,0 +1,69 @@
+package scraper
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// OutputSink is a destination for formatted scraping results, such as a file,
+// stdout or a remote store
+type OutputSink interface {
+	Write(result *ScrapingResult, format string) error
+}
+
+// FileSink writes results to a file, replacing it if it exists
+type FileSink struct {
+	Path string
+}
+
+// Write formats the result and writes it to the sink's file
+func (s FileSink) Write(result *ScrapingResult, format string) error {
+	content, err := result.Format(format)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path, []byte(content), 0644)
+}
+
+// WriterSink writes results to any io.Writer, e.g. os.Stdout or a bytes.Buffer
+type WriterSink struct {
+	W io.Writer
+}
+
+// Write formats the result and writes it to the sink's writer. A table written
+// to a terminal is colored unless NO_COLOR is set.
+func (s WriterSink) Write(result *ScrapingResult, format string) error {
+	content, err := result.Format(format)
+	if err != nil {
+		return err
+	}
+	if f := strings.ToLower(format); (f == "table" || f == "txt") && useColor(s.W) {
+		content = result.FormatAsColorTable(true)
+	}
+	if _, err := io.WriteString(s.W, content); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	return nil
+}
+
+// Format renders the result in the named format: json, table/txt, compact, tsv, html or reminders
+func (r *ScrapingResult) Format(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return r.FormatAsJSON(true)
+	case "table", "txt":
+		return r.FormatAsTable(), nil
+	case "compact":
+		return r.FormatAsCompactTable(), nil
+	case "tsv":
+		return r.FormatAsTSV()
+	case "html":
+		return r.FormatAsHTML()
+	case "reminders":
+		return r.FormatAsReminders(), nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s (supported: json, table, txt, compact, tsv, html, reminders)", format)
+	}
+}
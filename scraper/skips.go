@@ -0,0 +1,65 @@
+package scraper
+
+import (
+	"fmt"
+	"log"
+	"sort"
+)
+
+// Reasons a listing element is skipped instead of becoming an event
+const (
+	SkipReasonNoLink = "no_link" // The element has no ticket or match link
+	SkipReasonNoName = "no_name" // Neither an event name nor both teams could be read
+)
+
+// DefaultSkipWarnRatio is the share of skipped listing elements above which a scrape logs a warning
+const DefaultSkipWarnRatio = 0.2
+
+// recordSkip counts a listing element the parser couldn't turn into an event
+func (r *ScrapingResult) recordSkip(reason string) {
+	if r.SkipReasons == nil {
+		r.SkipReasons = make(map[string]int)
+	}
+	r.SkippedCount++
+	r.SkipReasons[reason]++
+}
+
+// AddSkips adds the skipped elements of part, e.g. a source merged into an "all" result
+func (r *ScrapingResult) AddSkips(part *ScrapingResult) {
+	for reason, count := range part.SkipReasons {
+		if r.SkipReasons == nil {
+			r.SkipReasons = make(map[string]int)
+		}
+		r.SkipReasons[reason] += count
+	}
+	r.SkippedCount += part.SkippedCount
+}
+
+// warnOnSkips logs a warning when more than ratio of the listing elements were
+// skipped, which usually means the site's layout changed. A ratio of 0 never warns.
+func warnOnSkips(source string, r *ScrapingResult, ratio float64) {
+	elements := len(r.Events) + r.SkippedCount
+	if ratio <= 0 || r.SkippedCount == 0 || float64(r.SkippedCount) <= ratio*float64(elements) {
+		return
+	}
+	log.Printf("WARNING: %s skipped %d of %d listing elements (%s); the page layout has probably changed",
+		source, r.SkippedCount, elements, formatSkipReasons(r.SkipReasons))
+}
+
+// formatSkipReasons lists skip counts by reason, e.g. "no_link: 3"
+func formatSkipReasons(reasons map[string]int) string {
+	keys := make([]string, 0, len(reasons))
+	for reason := range reasons {
+		keys = append(keys, reason)
+	}
+	sort.Strings(keys)
+
+	formatted := ""
+	for i, reason := range keys {
+		if i > 0 {
+			formatted += ", "
+		}
+		formatted += fmt.Sprintf("%s: %d", reason, reasons[reason])
+	}
+	return formatted
+}
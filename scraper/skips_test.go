@@ -0,0 +1,92 @@
+package scraper
+
+import (
+	"bytes"
+	"log"
+	"maps"
+	"strings"
+	"testing"
+)
+
+// captureLog collects what the standard logger writes during the test
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+	return &buf
+}
+
+func TestSkippedListingsCounted(t *testing.T) {
+	s := NewScraperWithOptions(testOptions())
+	result, err := s.ScrapeFromFile(writePage(t,
+		helloListing("/atletico/2263527/2", "Atlético de Madrid vs. Real Madrid CF", "27 Sep", "Sat", "4:15pm"),
+		helloListing("", "Real Madrid CF vs. Getafe", "4 Oct", "Sat"),
+		helloListing("/betis/2263529/2", "Real Betis vs. Sevilla FC", "5 Oct", "Sun"),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 2 {
+		t.Errorf("got %d events, want 2", result.Total)
+	}
+	if result.SkippedCount != 1 {
+		t.Errorf("SkippedCount = %d, want 1", result.SkippedCount)
+	}
+	if want := map[string]int{SkipReasonNoLink: 1}; !maps.Equal(result.SkipReasons, want) {
+		t.Errorf("SkipReasons = %v, want %v", result.SkipReasons, want)
+	}
+	if summary := result.GetSummary(); !strings.Contains(summary, "Skipped Listings: 1 (no_link: 1)") {
+		t.Errorf("summary %q doesn't report the skip", summary)
+	}
+	if stats := result.GetStatistics(); stats.Skipped != 1 || stats.SkipReasons[SkipReasonNoLink] != 1 {
+		t.Errorf("stats report %d skipped (%v), want 1 no_link", stats.Skipped, stats.SkipReasons)
+	}
+}
+
+func TestSkipWarningRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		ratio    float64
+		wantWarn bool
+	}{
+		{name: "above the ratio", ratio: 0.2, wantWarn: true},
+		{name: "at the ratio", ratio: 0.5, wantWarn: false},
+		{name: "disabled", ratio: 0, wantWarn: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			logs := captureLog(t)
+			opts := testOptions()
+			opts.SkipWarnRatio = tc.ratio
+			s := NewScraperWithOptions(opts)
+			_, err := s.ScrapeFromFile(writePage(t,
+				helloListing("/atletico/2263527/2", "Atlético de Madrid vs. Real Madrid CF", "27 Sep", "Sat"),
+				helloListing("", "Real Madrid CF vs. Getafe", "4 Oct", "Sat"),
+			))
+			if err != nil {
+				t.Fatal(err)
+			}
+			warned := strings.Contains(logs.String(), "hellotickets skipped 1 of 2 listing elements")
+			if warned != tc.wantWarn {
+				t.Errorf("warned = %v, want %v; logs: %s", warned, tc.wantWarn, logs)
+			}
+		})
+	}
+}
+
+func TestAddSkips(t *testing.T) {
+	merged := &ScrapingResult{}
+	merged.AddSkips(&ScrapingResult{SkippedCount: 2, SkipReasons: map[string]int{SkipReasonNoLink: 2}})
+	merged.AddSkips(&ScrapingResult{SkippedCount: 1, SkipReasons: map[string]int{SkipReasonNoLink: 1}})
+	merged.AddSkips(&ScrapingResult{SkippedCount: 1, SkipReasons: map[string]int{SkipReasonNoName: 1}})
+
+	if merged.SkippedCount != 4 {
+		t.Errorf("SkippedCount = %d, want 4", merged.SkippedCount)
+	}
+	if want := map[string]int{SkipReasonNoLink: 3, SkipReasonNoName: 1}; !maps.Equal(merged.SkipReasons, want) {
+		t.Errorf("SkipReasons = %v, want %v", merged.SkipReasons, want)
+	}
+}
@@ -0,0 +1,35 @@
+package scraper
+
+import "sort"
+
+// SortByOriginal restores the order in which the source site listed the events
+func (r *ScrapingResult) SortByOriginal() *ScrapingResult {
+	events := make([]TicketEvent, len(r.Events))
+	copy(events, r.Events)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OriginalIndex < events[j].OriginalIndex
+	})
+
+	return r.withEvents(events)
+}
+
+// SortByPrice orders events by their parsed price, cheapest first when ascending.
+// Events without a price go last either way, and equal prices keep their order.
+func (r *ScrapingResult) SortByPrice(ascending bool) *ScrapingResult {
+	events := make([]TicketEvent, len(r.Events))
+	copy(events, r.Events)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i].PriceValue, events[j].PriceValue
+		if a == 0 || b == 0 {
+			return b == 0 && a != 0
+		}
+		if ascending {
+			return a < b
+		}
+		return a > b
+	})
+
+	return r.withEvents(events)
+}
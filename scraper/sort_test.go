@@ -0,0 +1,41 @@
+package scraper
+
+import (
+	"slices"
+	"testing"
+)
+
+// eventNames lists the names of the result's events in order
+func eventNames(r *ScrapingResult) []string {
+	var names []string
+	for _, event := range r.Events {
+		names = append(names, event.Event)
+	}
+	return names
+}
+
+func TestSortByPriceSinksUnpriced(t *testing.T) {
+	result := &ScrapingResult{Events: []TicketEvent{
+		{Event: "no price A"},
+		{Event: "90", PriceValue: 90},
+		{Event: "40 first", PriceValue: 40},
+		{Event: "no price B"},
+		{Event: "250", PriceValue: 250},
+		{Event: "40 second", PriceValue: 40},
+	}}
+
+	asc := eventNames(result.SortByPrice(true))
+	if want := []string{"40 first", "40 second", "90", "250", "no price A", "no price B"}; !slices.Equal(asc, want) {
+		t.Errorf("ascending = %q, want %q", asc, want)
+	}
+
+	desc := eventNames(result.SortByPrice(false))
+	if want := []string{"250", "90", "40 first", "40 second", "no price A", "no price B"}; !slices.Equal(desc, want) {
+		t.Errorf("descending = %q, want %q", desc, want)
+	}
+
+	// The result itself is left in its order
+	if result.Events[0].Event != "no price A" {
+		t.Error("SortByPrice reordered the original result")
+	}
+}
@@ -0,0 +1,25 @@
+package scraper
+
+import "context"
+
+// SourceInfo describes a source and which event fields its listings fill in,
+// so clients know which columns to expect
+type SourceInfo struct {
+	Name           string `json:"name"`
+	DisplayName    string `json:"display_name"`    // How the source is shown to people, e.g. "Sport365"
+	Domain         string `json:"domain"`          // Where its pages are served from; credentials, locale cookies and detail scrapes stay on it
+	HasPrice       bool   `json:"has_price"`       // Price, PriceValue and Currency
+	HasVenue       bool   `json:"has_venue"`       // Venue; listings don't carry it, only match detail pages
+	HasTime        bool   `json:"has_time"`        // DateTime includes a kick-off time, not just a date
+	HasCompetition bool   `json:"has_competition"` // Competition, when the listing names one
+	UsesBrowser    bool   `json:"uses_browser"`    // Scraped with headless Chrome, so slower and queued for a browser slot
+	Cookies        bool   `json:"cookies"`         // Sends caller cookies from POST /scrape with its listing requests
+	BasicAuth      bool   `json:"basic_auth"`      // Sends caller basic auth from POST /scrape with its listing requests
+}
+
+// SourceScraper is the contract every source's scraper implements
+type SourceScraper interface {
+	Name() string
+	Scrape(ctx context.Context) (*ScrapingResult, error)
+	ScrapeFromFile(path string) (*ScrapingResult, error)
+}
@@ -0,0 +1,223 @@
+package scraper
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// capabilityFixtures holds a listing page for each built-in source, with every
+// field the source's markup can carry filled in
+var capabilityFixtures = map[string]string{
+	"hellotickets": `<html><body><ul><li class="performance performances-list__item">
+<a class="performance__link" href="/spain/madrid/sports/real-madrid-tickets/2025-09-27,1615/2263527/2">
+<div class="performance__date"><div class="performance__date-month">27 Sep</div><div class="performance__date-day"><p>Sat</p><p>4:15pm</p></div></div>
+<div class="performance__description"><span class="performance__description__name">Real Madrid CF vs. Getafe - LaLiga</span></div>
+<div class="performance__price">From €95</div>
+</a>
+</li></ul></body></html>`,
+	"vividseats": `<html><body><div data-testid="production-listing-1">
+<a class="styles_linkContainer__4li3j" href="/real-madrid-tickets/production/5631001">
+<div data-testid="date-time-left-element">
+<span class="MuiTypography-small-bold">Oct 26</span>
+<span class="MuiTypography-overline">Sun</span>
+<span class="MuiTypography-caption">4:15pm</span>
+</div>
+<span class="styles_titleTruncate__XiZ53">Real Madrid vs Barcelona - LaLiga</span>
+<span data-testid="listing-price">$250</span>
+</a>
+</div></body></html>`,
+	"sport365": `<html><body><a class="match-row" href="/football/match/real-madrid-barcelona/1-5523171">
+<div class="match-col status"><div class="status-content">26 Oct</div></div>
+<div class="match-col home-team"><span class="team-name">Real Madrid</span></div>
+<div class="match-col away-team"><span class="team-name">Barcelona</span></div>
+<div class="match-col round">LaLiga - Round 10</div>
+</a></body></html>`,
+}
+
+// clockTime matches a kick-off time in a datetime, e.g. "4:15pm"
+var clockTime = regexp.MustCompile(`\d{1,2}:\d{2}`)
+
+// scrapeFixture parses the source's capability fixture with its scraper
+func scrapeFixture(t *testing.T, source string) (SourceScraper, *ScrapingResult) {
+	t.Helper()
+	page, ok := capabilityFixtures[source]
+	if !ok {
+		t.Fatalf("no fixture for %s", source)
+	}
+	path := filepath.Join(t.TempDir(), source+".html")
+	if err := os.WriteFile(path, []byte(page), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, _ := NewSourceScraper(source, testOptions())
+	result, err := s.ScrapeFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total == 0 {
+		t.Fatal("fixture gave no events")
+	}
+	return s, result
+}
+
+func TestSourceCapabilitiesMatchOutput(t *testing.T) {
+	for _, info := range SourceInfos() {
+		t.Run(info.Name, func(t *testing.T) {
+			s, result := scrapeFixture(t, info.Name)
+
+			var got SourceInfo
+			for _, event := range result.Events {
+				got.HasPrice = got.HasPrice || event.PriceValue > 0
+				got.HasVenue = got.HasVenue || event.Venue != ""
+				got.HasTime = got.HasTime || clockTime.MatchString(event.DateTime)
+				got.HasCompetition = got.HasCompetition || event.Competition != ""
+			}
+			if got.HasPrice != info.HasPrice || got.HasVenue != info.HasVenue || got.HasTime != info.HasTime || got.HasCompetition != info.HasCompetition {
+				t.Errorf("registered %+v, but the output has price %v, venue %v, time %v, competition %v",
+					info, got.HasPrice, got.HasVenue, got.HasTime, got.HasCompetition)
+			}
+
+			_, browser := s.(*Sport365Scraper)
+			if browser != info.UsesBrowser {
+				t.Errorf("uses_browser = %v, want %v", info.UsesBrowser, browser)
+			}
+		})
+	}
+}
+
+func TestRegistryInfos(t *testing.T) {
+	r := NewRegistry()
+	factory := func(opts ScraperOptions) SourceScraper { return NewScraperWithOptions(opts) }
+	r.Register(SourceInfo{Name: "b", DisplayName: "B"}, factory)
+	r.Register(SourceInfo{Name: "a", DisplayName: "A"}, factory)
+	r.Register(SourceInfo{Name: "b", DisplayName: "B2", HasPrice: true}, factory)
+
+	infos := r.Infos()
+	if len(infos) != 2 || infos[0].Name != "b" || infos[1].Name != "a" {
+		t.Fatalf("Infos() = %+v, want b then a", infos)
+	}
+	if info, ok := r.Info("b"); !ok || info.DisplayName != "B2" || !info.HasPrice {
+		t.Errorf("Info(b) = %+v, %v; want the replacement", info, ok)
+	}
+	if _, ok := r.Info("missing"); ok {
+		t.Error("Info reported an unregistered source")
+	}
+}
+
+func TestParsersSetScrapedAt(t *testing.T) {
+	for _, source := range SourceNames() {
+		t.Run(source, func(t *testing.T) {
+			before := time.Now()
+			_, result := scrapeFixture(t, source)
+			for _, event := range result.Events {
+				if event.ScrapedAt.Before(before) || event.ScrapedAt.After(time.Now()) {
+					t.Errorf("%q scraped at %v, want the time of the scrape", event.Event, event.ScrapedAt)
+				}
+			}
+		})
+	}
+
+	// Unset, the field is left out of the JSON
+	data, err := json.Marshal(TicketEvent{Event: "Real Madrid vs Getafe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "scraped_at") {
+		t.Errorf("unset scraped_at marshalled: %s", data)
+	}
+}
+
+func TestBuiltInSourcesShareContract(t *testing.T) {
+	for _, source := range SourceNames() {
+		t.Run(source, func(t *testing.T) {
+			s, result := scrapeFixture(t, source)
+			if s.Name() != source {
+				t.Errorf("Name() = %q, want the registered %q", s.Name(), source)
+			}
+			for _, event := range result.Events {
+				if event.Source != source {
+					t.Errorf("%q has source %q, want %q", event.Event, event.Source, source)
+				}
+			}
+		})
+	}
+
+	if _, ok := NewSourceScraper("ticketmaster", testOptions()); ok || IsSource("ticketmaster") {
+		t.Error("an unregistered source was built")
+	}
+}
+
+func TestRegisteredSourceIsBuilt(t *testing.T) {
+	r := NewRegistry()
+	var got ScraperOptions
+	r.Register(SourceInfo{Name: "custom"}, func(opts ScraperOptions) SourceScraper {
+		got = opts
+		return NewScraperWithOptions(opts)
+	})
+
+	factory, ok := r.Get("custom")
+	if !ok {
+		t.Fatal("Get(custom) found nothing")
+	}
+	opts := testOptions()
+	opts.Language = "es"
+	if s := factory(opts); s == nil || got.Language != "es" {
+		t.Errorf("factory built %v with options %+v, want the given options", s, got)
+	}
+	if names := r.Names(); len(names) != 1 || names[0] != "custom" {
+		t.Errorf("Names() = %v, want [custom]", names)
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	r := NewRegistry()
+	factory := func(opts ScraperOptions) SourceScraper { return NewScraperWithOptions(opts) }
+	r.Register(SourceInfo{Name: "a"}, factory)
+	r.Register(SourceInfo{Name: "b"}, factory)
+	r.Unregister("a")
+	r.Unregister("missing")
+
+	if _, ok := r.Get("a"); ok {
+		t.Error("Get(a) found the unregistered source")
+	}
+	if names := r.Names(); len(names) != 1 || names[0] != "b" {
+		t.Errorf("Names() = %v, want [b]", names)
+	}
+}
+
+func TestRegisterScraper(t *testing.T) {
+	r := NewRegistry()
+	built := NewScraperWithOptions(testOptions())
+	r.RegisterScraper("mysite", func() SourceScraper { return built })
+
+	info, ok := r.Info("mysite")
+	if !ok {
+		t.Fatal("Info(mysite) found nothing")
+	}
+	if want := (SourceInfo{Name: "mysite", DisplayName: "mysite"}); info != want {
+		t.Errorf("Info(mysite) = %+v, want %+v", info, want)
+	}
+	factory, ok := r.Get("mysite")
+	if !ok {
+		t.Fatal("Get(mysite) found nothing")
+	}
+	if got := factory(DefaultScraperOptions()); got != built {
+		t.Errorf("factory built %v, want the registered scraper", got)
+	}
+}
+
+func TestSourceDomainsFromRegistry(t *testing.T) {
+	for _, info := range SourceInfos() {
+		if err := ValidateSourceURL(info.Name, "https://www."+info.Domain+"/match/1"); err != nil {
+			t.Errorf("%s: %v", info.Name, err)
+		}
+		if err := ValidateSourceURL(info.Name, "https://example.com/match/1"); err == nil {
+			t.Errorf("%s accepted a page off %s", info.Name, info.Domain)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package scraper
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// eventSources returns the component sources of an event; merged events carry
+// a comma-separated list such as "hellotickets,vividseats"
+func eventSources(event TicketEvent) []string {
+	var sources []string
+	for _, source := range strings.Split(event.Source, ",") {
+		source = strings.TrimSpace(source)
+		if source != "" {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// SplitBySource partitions the events into one result per source. Events from
+// several sources are assigned to each of them.
+func (r *ScrapingResult) SplitBySource() map[string]*ScrapingResult {
+	split := make(map[string]*ScrapingResult)
+
+	for _, event := range r.Events {
+		for _, source := range eventSources(event) {
+			part, exists := split[source]
+			if !exists {
+				part = &ScrapingResult{
+					Events:    []TicketEvent{},
+					Timestamp: r.Timestamp,
+					Source:    source,
+				}
+				if r.Source == source {
+					part.SourceURL = r.SourceURL
+				} else if url, ok := r.SourceURLs[source]; ok {
+					part.SourceURL = url
+				}
+				if status, ok := r.SourceStatus[source]; ok {
+					part.SourceStatus = map[string]string{source: status}
+				}
+				split[source] = part
+			}
+			part.Events = append(part.Events, event)
+		}
+	}
+
+	for _, part := range split {
+		part.Total = len(part.Events)
+	}
+
+	return split
+}
+
+// SaveBySource writes one file per source (e.g. source_hellotickets.json) into dir
+func (r *ScrapingResult) SaveBySource(dir, format string) error {
+	return r.WriteBySource(format, func(source string) OutputSink {
+		return FileSink{Path: filepath.Join(dir, fmt.Sprintf("source_%s.%s", source, strings.ToLower(format)))}
+	})
+}
+
+// WriteBySource splits the result by source and writes each part to the sink
+// returned by sinkFor, in source name order
+func (r *ScrapingResult) WriteBySource(format string, sinkFor func(source string) OutputSink) error {
+	split := r.SplitBySource()
+
+	sources := make([]string, 0, len(split))
+	for source := range split {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		if err := sinkFor(source).Write(split[source], format); err != nil {
+			return fmt.Errorf("failed to save %s results: %w", source, err)
+		}
+	}
+
+	return nil
+}
@@ -8,23 +8,68 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
+	"golang.org/x/time/rate"
 )
 
 // Sport365Scraper handles Sport365 web scraping operations using ChromeDP
 type Sport365Scraper struct {
-	baseURL string
+	baseURL    string
+	timeout    time.Duration
+	selectors  Selectors
+	limiter    *rate.Limiter
+	language   string
+	userAgent  string
+	captureRaw bool
+
+	skipWarnRatio float64
+	credentials   Credentials
 }
 
 // NewSport365Scraper creates a new Sport365 scraper instance
 func NewSport365Scraper() *Sport365Scraper {
+	return NewSport365ScraperWithOptions(DefaultScraperOptions())
+}
+
+// NewSport365ScraperWithOptions creates a new Sport365 scraper instance with the given options
+func NewSport365ScraperWithOptions(opts ScraperOptions) *Sport365Scraper {
 	return &Sport365Scraper{
-		baseURL: "https://www.sport365.com",
+		baseURL:    "https://www.sport365.com",
+		timeout:    opts.Timeout,
+		selectors:  opts.Selectors.withDefaults(DefaultSport365Selectors()),
+		limiter:    opts.Limiter,
+		language:   opts.Language,
+		userAgent:  opts.userAgent(),
+		captureRaw: opts.CaptureRaw,
+
+		skipWarnRatio: opts.SkipWarnRatio,
+		credentials:   opts.Credentials,
 	}
 }
 
+// SetLanguage sets the browser language, which Chrome sends as Accept-Language, e.g. "en" or "es"
+func (s *Sport365Scraper) SetLanguage(lang string) {
+	s.language = lang
+}
+
+// Name returns the source name, "sport365"
+func (s *Sport365Scraper) Name() string {
+	return "sport365"
+}
+
+// Scrape scrapes the source's fixtures page, aborting when ctx is done
+func (s *Sport365Scraper) Scrape(ctx context.Context) (*ScrapingResult, error) {
+	return s.ScrapeSport365RealMadridMatchesContext(ctx)
+}
+
 // ScrapeSport365RealMadridMatches scrapes the Sport365 Real Madrid fixtures page using ChromeDP
 func (s *Sport365Scraper) ScrapeSport365RealMadridMatches() (*ScrapingResult, error) {
+	return s.ScrapeSport365RealMadridMatchesContext(context.Background())
+}
+
+// ScrapeSport365RealMadridMatchesContext scrapes the Sport365 fixtures page, aborting when parent is done
+func (s *Sport365Scraper) ScrapeSport365RealMadridMatchesContext(parent context.Context) (*ScrapingResult, error) {
 	url := "https://www.sport365.com/football/team/real-madrid/1-1973#/fixtures"
 
 	result := &ScrapingResult{
@@ -36,22 +81,39 @@ func (s *Sport365Scraper) ScrapeSport365RealMadridMatches() (*ScrapingResult, er
 
 	log.Printf("Scraping Sport365 with ChromeDP: %s", url)
 
-	// Create context with timeout
-	ctx, cancel := chromedp.NewContext(context.Background())
+	// Wait for a slot in the global request budget before launching the browser
+	if err := waitLimiter(parent, s.limiter); err != nil {
+		return result, newScrapeError("sport365", url, ErrorKindTimeout, fmt.Errorf("request budget wait cancelled: %w", err))
+	}
+
+	// Queue for a browser slot so concurrent scrapes don't all launch Chrome at once
+	release, err := browsers.acquire(parent)
+	if err != nil {
+		return result, newScrapeError("sport365", url, ErrorKindTimeout, fmt.Errorf("browser slot wait cancelled: %w", err))
+	}
+	defer release()
+
+	// Start the browser from the shared allocator for the configured language
+	// and User-Agent, closing it when parent is done
+	ctx, cancel := chromedp.NewContext(browsers.allocator(s.language, s.userAgent))
 	defer cancel()
+	stop := context.AfterFunc(parent, cancel)
+	defer stop()
 
 	// Set timeout
-	ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+	ctx, cancel = context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
 	var htmlContent string
 
 	// Run ChromeDP tasks
-	err := chromedp.Run(ctx,
+	err = chromedp.Run(ctx,
+		// Set any caller cookies before the first request
+		s.setCookies(),
 		// Navigate to the page
 		chromedp.Navigate(url),
 		// Wait for the page to load and JavaScript to execute
-		chromedp.WaitVisible("a.match-row", chromedp.ByQuery),
+		chromedp.WaitVisible(s.selectors.Listing, chromedp.ByQuery),
 		// Wait a bit more for dynamic content to load
 		chromedp.Sleep(3*time.Second),
 		// Get the full HTML content
@@ -60,24 +122,17 @@ func (s *Sport365Scraper) ScrapeSport365RealMadridMatches() (*ScrapingResult, er
 
 	if err != nil {
 		log.Printf("ChromeDP failed to scrape %s: %v", url, err)
-		return result, fmt.Errorf("failed to scrape with ChromeDP: %w", err)
+		return result, newFetchError("sport365", url, fmt.Errorf("failed to scrape with ChromeDP: %w", err))
 	}
 
 	// Parse the HTML content with goquery
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
 	if err != nil {
-		return result, fmt.Errorf("failed to parse HTML: %w", err)
+		return result, newScrapeError("sport365", url, ErrorKindParse, fmt.Errorf("failed to parse HTML: %w", err))
 	}
 
 	// Extract match events
-	doc.Find("a.match-row").Each(func(i int, sel *goquery.Selection) {
-		event := s.parseSport365SelectionEvent(sel)
-		if event != nil {
-			result.Events = append(result.Events, *event)
-		}
-	})
-
-	result.Total = len(result.Events)
+	s.parseDocument(doc, result)
 
 	if result.Total > 0 {
 		log.Printf("Successfully scraped %d events from Sport365", result.Total)
@@ -88,38 +143,106 @@ func (s *Sport365Scraper) ScrapeSport365RealMadridMatches() (*ScrapingResult, er
 	return result, nil
 }
 
-// parseSport365SelectionEvent parses a goquery selection into a TicketEvent
-func (s *Sport365Scraper) parseSport365SelectionEvent(sel *goquery.Selection) *TicketEvent {
+// ScrapeFromFile runs the Sport365 parser over a saved HTML page instead of the live site
+func (s *Sport365Scraper) ScrapeFromFile(path string) (*ScrapingResult, error) {
+	result := &ScrapingResult{
+		Events:    []TicketEvent{},
+		Timestamp: time.Now(),
+		SourceURL: path,
+		Source:    "sport365",
+	}
+
+	doc, err := loadFixture(path)
+	if err != nil {
+		return nil, newScrapeError("sport365", path, ErrorKindParse, err)
+	}
+
+	s.parseDocument(doc, result)
+	return result, nil
+}
+
+// parseDocument extracts every match row of a rendered fixtures page into result
+func (s *Sport365Scraper) parseDocument(doc *goquery.Document, result *ScrapingResult) {
+	doc.Find(s.selectors.Listing).Each(func(i int, sel *goquery.Selection) {
+		event, skipReason := s.parseSport365SelectionEvent(sel)
+		if event == nil {
+			result.recordSkip(skipReason)
+			return
+		}
+		event.OriginalIndex = len(result.Events)
+		result.Events = append(result.Events, *event)
+	})
+
+	warnOnSkips("sport365", result, s.skipWarnRatio)
+	result.Total = len(result.Events)
+}
+
+// parseSport365SelectionEvent parses a goquery selection into a TicketEvent,
+// returning a nil event and the reason when the row has to be skipped
+func (s *Sport365Scraper) parseSport365SelectionEvent(sel *goquery.Selection) (*TicketEvent, string) {
 	// Extract link
 	link, _ := sel.Attr("href")
 	if link == "" {
-		return nil
+		return nil, SkipReasonNoLink
 	}
 
 	// Convert to full URL
-	if !strings.HasPrefix(link, "http") {
-		link = s.baseURL + link
-	}
+	link = resolveURL(s.baseURL, link)
 
 	// Extract date from status column
-	date := strings.TrimSpace(sel.Find(".match-col.status .status-content").Text())
+	date := cleanText(sel.Find(s.selectors.Date).Text())
 
 	// Extract home team name
-	homeTeam := strings.TrimSpace(sel.Find(".match-col.home-team .team-name").Text())
+	homeTeam := cleanText(sel.Find(s.selectors.HomeTeam).Text())
 
 	// Extract away team name
-	awayTeam := strings.TrimSpace(sel.Find(".match-col.away-team .team-name").Text())
+	awayTeam := cleanText(sel.Find(s.selectors.AwayTeam).Text())
+
+	// Extract round, preferring a dedicated column and falling back to the row text
+	round := extractRound(sel.Find(".match-col.round, .round-name").Text())
+	if round == "" {
+		round = extractRound(sel.Text())
+	}
+	competition := detectCompetition(sel.Text())
 
 	// Create event name
 	event := fmt.Sprintf("%s vs. %s", homeTeam, awayTeam)
 
-	// Format datetime (Sport365 only provides date, no time)
-	datetime := date
+	// Format datetime (Sport365 only provides date, no time); a multi-day event keeps its first day
+	datetime, startDate, endDate := splitDateRange(date)
 
 	return &TicketEvent{
-		DateTime: datetime,
-		Event:    event,
-		Link:     link,
-		Source:   "sport365",
-	}
+		DateTime:    datetime,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Event:       event,
+		Link:        link,
+		Source:      "sport365",
+		HomeTeam:    homeTeam,
+		AwayTeam:    awayTeam,
+		Round:       round,
+		Competition: competition,
+		Meta:        eventMeta(MetaSport365MatchID, sport365MatchID(link)),
+		ScrapedAt:   time.Now(),
+		RawHTML:     rawHTML(sel, s.captureRaw),
+	}, ""
+}
+
+// setCookies sets the caller's cookies in the browser, scoped to Sport365's
+// domain. Basic auth isn't supported here, as Chrome would send it to every site.
+func (s *Sport365Scraper) setCookies() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		domain, _ := sourceDomain("sport365")
+		for _, cookie := range s.credentials.Cookies {
+			err := network.SetCookie(cookie.Name, cookie.Value).
+				WithDomain("." + domain).
+				WithPath("/").
+				WithSecure(true).
+				Do(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to set cookie %q: %w", cookie.Name, err)
+			}
+		}
+		return nil
+	})
 }
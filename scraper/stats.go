@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"sort"
+	"time"
+)
+
+// Statistics summarizes a scraping result
+type Statistics struct {
+	Total             int            `json:"total"`
+	RawTotal          int            `json:"raw_total"`          // Events before duplicates were collapsed
+	DuplicatesRemoved int            `json:"duplicates_removed"` // How much the sources overlapped
+	BySource          map[string]int `json:"by_source"`
+	Dated             int            `json:"dated"`
+	Undated           int            `json:"undated"`
+	Earliest          *time.Time     `json:"earliest,omitempty"`
+	Latest            *time.Time     `json:"latest,omitempty"`
+	SuggestedRefresh  string         `json:"suggested_refresh"`      // How soon a re-scrape is worthwhile, e.g. "1h0m0s"
+	Skipped           int            `json:"skipped"`                // Listing elements the parsers couldn't turn into events
+	SkipReasons       map[string]int `json:"skip_reasons,omitempty"` // Skipped elements by reason
+}
+
+// GetStatistics computes counts per source, date coverage and dedup metrics
+func (r *ScrapingResult) GetStatistics() Statistics {
+	stats := Statistics{
+		Total:             len(r.Events),
+		RawTotal:          rawTotal(r),
+		DuplicatesRemoved: r.DuplicatesRemoved,
+		BySource:          make(map[string]int),
+		Skipped:           r.SkippedCount,
+		SkipReasons:       r.SkipReasons,
+	}
+
+	var dates []time.Time
+	for _, event := range r.Events {
+		for _, source := range eventSources(event) {
+			stats.BySource[source]++
+		}
+
+		eventDate, err := parseEventDate(event.DateTime)
+		if err != nil {
+			stats.Undated++
+			continue
+		}
+		stats.Dated++
+		dates = append(dates, eventDate)
+	}
+
+	if len(dates) > 0 {
+		sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+		stats.Earliest = &dates[0]
+		stats.Latest = &dates[len(dates)-1]
+	}
+
+	stats.SuggestedRefresh = r.SuggestRefreshInterval().String()
+	return stats
+}
+
+// SuggestRefreshInterval suggests how long to wait before scraping again, based on
+// how soon the next upcoming event is: listings change faster as match day approaches
+func (r *ScrapingResult) SuggestRefreshInterval() time.Duration {
+	now := time.Now()
+
+	var next time.Time
+	for _, event := range r.Events {
+		eventDate, err := parseEventDate(event.DateTime)
+		if err != nil || eventDate.Before(now) {
+			continue
+		}
+		if next.IsZero() || eventDate.Before(next) {
+			next = eventDate
+		}
+	}
+
+	// Nothing upcoming to watch, so check back occasionally
+	if next.IsZero() {
+		return 6 * time.Hour
+	}
+
+	switch until := next.Sub(now); {
+	case until <= 2*24*time.Hour:
+		return 15 * time.Minute
+	case until <= 7*24*time.Hour:
+		return time.Hour
+	case until <= 30*24*time.Hour:
+		return 6 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
@@ -0,0 +1,140 @@
+package scraper
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// accentReplacer folds the accented Latin characters that appear in team names
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c",
+	"Á", "A", "À", "A", "Â", "A", "Ä", "A", "Ã", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Ö", "O", "Õ", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ñ", "N", "Ç", "C",
+)
+
+// foldAccents removes diacritics so "Atlético" compares equal to "Atletico"
+func foldAccents(s string) string {
+	return accentReplacer.Replace(s)
+}
+
+// TeamSlug converts a team name into its URL slug, e.g. "Atlético Madrid" -> "atletico-madrid"
+func TeamSlug(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(foldAccents(name)), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(fields, "-")
+}
+
+// TeamSlugs returns the sorted slugs of every canonical team the normalizer knows
+func (n *TeamNameNormalizer) TeamSlugs() []string {
+	seen := make(map[string]bool)
+	var slugs []string
+
+	for _, canonical := range n.teamMappings {
+		slug := TeamSlug(canonical)
+		if !seen[slug] {
+			seen[slug] = true
+			slugs = append(slugs, slug)
+		}
+	}
+
+	sort.Strings(slugs)
+	return slugs
+}
+
+// ResolveTeamSlug returns the canonical team name for a slug
+func (n *TeamNameNormalizer) ResolveTeamSlug(slug string) (string, bool) {
+	slug = TeamSlug(slug)
+	for _, canonical := range n.teamMappings {
+		if TeamSlug(canonical) == slug {
+			return canonical, true
+		}
+	}
+	return "", false
+}
+
+// SuggestTeamSlugs returns up to max known slugs most similar to an unknown slug
+func (n *TeamNameNormalizer) SuggestTeamSlugs(slug string, max int) []string {
+	type candidate struct {
+		slug  string
+		score float64
+	}
+
+	slug = TeamSlug(slug)
+	var candidates []candidate
+	for _, known := range n.TeamSlugs() {
+		score := similarity(slug, known)
+		if score >= n.similarityThreshold {
+			candidates = append(candidates, candidate{slug: known, score: score})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(candidates) && i < max; i++ {
+		suggestions = append(suggestions, candidates[i].slug)
+	}
+	return suggestions
+}
+
+// FilterByTeam keeps only events in which the given canonical team plays
+func (n *TeamNameNormalizer) FilterByTeam(result *ScrapingResult, canonical string) *ScrapingResult {
+	return n.FilterByTeams(result, canonical)
+}
+
+// FilterByTeams keeps only events in which any of the given canonical teams plays
+func (n *TeamNameNormalizer) FilterByTeams(result *ScrapingResult, canonicals ...string) *ScrapingResult {
+	filtered := result.withEvents([]TicketEvent{})
+
+	for _, event := range result.Events {
+		normalizedName := n.normalizeEventName(event.Event)
+		for _, team := range strings.Split(normalizedName, " vs ") {
+			if slices.Contains(canonicals, strings.TrimSpace(team)) {
+				filtered.Events = append(filtered.Events, event)
+				break
+			}
+		}
+	}
+
+	filtered.Total = len(filtered.Events)
+	return filtered
+}
+
+// teamAcronyms are the club-name abbreviations written in capitals, e.g. "RCD Espanyol"
+var teamAcronyms = map[string]bool{
+	"fc": true, "cf": true, "rcd": true, "ca": true, "ud": true, "cd": true,
+	"sd": true, "rc": true, "ac": true, "afc": true, "ssc": true, "psv": true,
+}
+
+// titleCaseTeam capitalizes a team name that isn't in the mappings, keeping
+// club abbreviations such as "RCD" in capitals
+func titleCaseTeam(name string) string {
+	// A Caser keeps state between calls, so each name gets its own
+	caser := cases.Title(language.Und)
+
+	words := strings.Fields(name)
+	for i, word := range words {
+		if teamAcronyms[strings.ToLower(word)] {
+			words[i] = strings.ToUpper(word)
+		} else {
+			words[i] = caser.String(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
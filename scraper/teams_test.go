@@ -0,0 +1,27 @@
+package scraper
+
+import "testing"
+
+func TestTitleCaseTeamKeepsAcronyms(t *testing.T) {
+	for name, want := range map[string]string{
+		"rcd espanyol":           "RCD Espanyol",
+		"RCD ESPANYOL":           "RCD Espanyol",
+		"ca osasuna":             "CA Osasuna",
+		"ud las palmas":          "UD Las Palmas",
+		"inter miami cf":         "Inter Miami CF",
+		"psv eindhoven":          "PSV Eindhoven",
+		"newell's old boys":      "Newell's Old Boys",
+		"  deportivo   alavés  ": "Deportivo Alavés",
+	} {
+		if got := titleCaseTeam(name); got != want {
+			t.Errorf("titleCaseTeam(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestUnmappedTeamsAreTitleCased(t *testing.T) {
+	n := NewTeamNameNormalizer()
+	if got := n.normalizeEventName("real madrid vs ud logroñés"); got != "Real Madrid vs UD Logroñés" {
+		t.Errorf("normalizeEventName = %q", got)
+	}
+}
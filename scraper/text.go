@@ -0,0 +1,20 @@
+package scraper
+
+import "strings"
+
+// invisibleChars removes zero-width characters sites put in names, e.g. to
+// allow line breaks, which don't show but break matching
+var invisibleChars = strings.NewReplacer(
+	"\u200b", "", // Zero-width space
+	"\u200c", "", // Zero-width non-joiner
+	"\u200d", "", // Zero-width joiner
+	"\u2060", "", // Word joiner
+	"\ufeff", "", // Zero-width no-break space (byte order mark)
+	"\u00ad", "", // Soft hyphen
+)
+
+// cleanText strips zero-width characters and collapses every run of Unicode
+// whitespace, including non-breaking spaces, to a single ASCII space
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(invisibleChars.Replace(s)), " ")
+}
@@ -0,0 +1,46 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestCleanText(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"Real Madrid vs Getafe", "Real Madrid vs Getafe"},
+		{"Real\u00a0Madrid vs\u00a0Getafe", "Real Madrid vs Getafe"},
+		{"  Real   Madrid\tvs\nGetafe ", "Real Madrid vs Getafe"},
+		{"Real Ma\u200bdrid vs Ge\u00adtafe\ufeff", "Real Madrid vs Getafe"},
+		{"\u200b\u00a0", ""},
+	} {
+		if got := cleanText(tc.in); got != tc.want {
+			t.Errorf("cleanText(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestScrapedNamesMatchAcrossNonBreakingSpaces(t *testing.T) {
+	opts := testOptions()
+	opts.MaxPages = 1
+	opts.VividSeatsPerformers = []string{"11111"}
+	s := NewVividSeatsScraperWithOptions(opts)
+	mockSite(t, s.collector, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(vividPage("",
+			vividListing("/real-madrid-vs-getafe/production/1", "Real&nbsp;Madrid vs\u200b Getafe", "Sep\u00a02", "Sat", "9:00pm"),
+		)))
+	}))
+
+	result, err := s.Scrape(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 1 || result.Events[0].Event != "Real Madrid vs Getafe" {
+		t.Fatalf("scraped %+v, want the cleaned name", result.Events)
+	}
+	if filtered := result.FilterByKeyword("real madrid"); filtered.Total != 1 {
+		t.Errorf("FilterByKeyword(%q) kept %d events, want 1", "real madrid", filtered.Total)
+	}
+}
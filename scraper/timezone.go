@@ -0,0 +1,74 @@
+package scraper
+
+import "time"
+
+// eventTimeFormats are the datetime layouts that carry a time of day; date-only
+// listings can't be converted between zones meaningfully
+var eventTimeFormats = []string{
+	"02 Jan Mon 3:04pm", // "27 Sep Sat 4:15pm"
+	"Jan 02 Mon 3:04pm", // "Sep 27 Sat 4:15pm"
+}
+
+// displayTimeFormat is the layout converted datetimes are written in, with the zone abbreviation appended
+const displayTimeFormat = "02 Jan Mon 3:04pm MST"
+
+// parseEventTime parses a datetime that includes a time of day, interpreting it
+// in loc. Listings without a year are assumed to be the next occurrence of that date.
+func parseEventTime(dateTimeStr string, loc *time.Location) (time.Time, bool) {
+	for _, format := range eventTimeFormats {
+		t, err := time.ParseInLocation(format, dateTimeStr, loc)
+		if err != nil {
+			continue
+		}
+
+		now := time.Now().In(loc)
+		year := now.Year()
+		if t.Month() < now.Month() || (t.Month() == now.Month() && t.Day() < now.Day()) {
+			year++
+		}
+		return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc), true
+	}
+	return time.Time{}, false
+}
+
+// DefaultDateLayout is the layout CanonicalizeDates uses when none is given
+const DefaultDateLayout = "Mon 02 Jan 2006 15:04"
+
+// CanonicalizeDates rewrites every event's datetime in one layout (a Go time
+// layout; empty uses DefaultDateLayout) so sources read alike. Date-only
+// listings get midnight. Datetimes that can't be parsed are left as they are
+// and flagged with DateUnparsed.
+func (r *ScrapingResult) CanonicalizeDates(layout string) *ScrapingResult {
+	if layout == "" {
+		layout = DefaultDateLayout
+	}
+
+	canonical := r.withEvents(make([]TicketEvent, len(r.Events)))
+
+	for i, event := range r.Events {
+		if t, err := parseEventDate(event.DateTime); err == nil {
+			event.DateTime = t.Format(layout)
+		} else {
+			event.DateUnparsed = true
+		}
+		canonical.Events[i] = event
+	}
+
+	return canonical
+}
+
+// ConvertTimezone rewrites each event's datetime from the source zone to the
+// display zone, e.g. "27 Sep Sat 4:15pm" in Madrid becomes "27 Sep Sat 10:15am EDT"
+// in New York. Events without a parseable time are left unchanged.
+func (r *ScrapingResult) ConvertTimezone(source, display *time.Location) *ScrapingResult {
+	converted := r.withEvents(make([]TicketEvent, len(r.Events)))
+
+	for i, event := range r.Events {
+		if t, ok := parseEventTime(event.DateTime, source); ok {
+			event.DateTime = t.In(display).Format(displayTimeFormat)
+		}
+		converted.Events[i] = event
+	}
+
+	return converted
+}
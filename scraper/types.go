@@ -1,6 +1,9 @@
 package scraper
 
-import "time"
+import (
+	"maps"
+	"time"
+)
 
 // TicketEvent represents a single ticket event with essential information only
 type TicketEvent struct {
@@ -8,8 +11,42 @@ type TicketEvent struct {
 	Event    string `json:"event"`    // e.g., "Atlético de Madrid vs. Real Madrid CF"
 	Link     string `json:"link"`     // e.g., "/spain/madrid/sports/..."
 	Source   string `json:"source"`   // e.g., "hellotickets" or "vividseats"
+
+	StartDate string `json:"start_date,omitempty"` // First day of a multi-day event such as a tournament, e.g. "2025-09-27"; DateTime reads as this day
+	EndDate   string `json:"end_date,omitempty"`   // Last day of a multi-day event
+
+	HomeTeam      string `json:"home_team,omitempty"` // Set by sources that list teams separately and by normalization
+	AwayTeam      string `json:"away_team,omitempty"`
+	Round         string `json:"round,omitempty"`         // e.g., "Matchday 12" or "Round of 16"
+	Competition   string `json:"competition,omitempty"`   // e.g., "La Liga" or "Champions League", when the listing names it
+	OriginalIndex int    `json:"original_index"`          // Position on the source page, i.e. the site's own ranking
+	ImageURL      string `json:"image_url,omitempty"`     // Thumbnail of the performer or venue
+	OriginalLink  string `json:"original_link,omitempty"` // Link before tracking params were stripped
+	Type          string `json:"type,omitempty"`          // "match", "parking" or "package"; set by VividSeats
+	Status        string `json:"status,omitempty"`        // "date_tbd" when the listing has no usable date
+	Multi         bool   `json:"multi,omitempty"`         // Set by normalization when the listing covers several matches; its name is kept as listed
+	DoorsTime     string `json:"doors_time,omitempty"`    // When the doors open, if the listing says; DateTime holds the kick-off
+	DateUnparsed  bool   `json:"date_unparsed,omitempty"` // Set when canonical dates were requested but this one couldn't be read
+	Past          bool   `json:"past,omitempty"`          // Dated before today; set when past events are flagged
+	Venue         string `json:"venue,omitempty"`         // Stadium and city; set by match detail pages
+	Availability  string `json:"availability,omitempty"`  // "available", "limited", "sold_out" or "cancelled"; set by match detail pages
+
+	Price        string `json:"price"`                   // Price as shown on the listing, e.g. "From $120"; empty for fixtures-only sources such as Sport365
+	PriceValue   Price  `json:"price_value,omitempty"`   // Parsed amount; the cheapest when listings are collapsed
+	PriceMin     Price  `json:"price_min,omitempty"`     // Cheapest of the collapsed listings
+	PriceMax     Price  `json:"price_max,omitempty"`     // Dearest of the collapsed listings
+	Currency     string `json:"currency,omitempty"`      // ISO code, e.g. "EUR"
+	ListingCount int    `json:"listing_count,omitempty"` // Listings collapsed into this event
+
+	NormalizedConfidence float64           `json:"normalized_confidence,omitempty"` // Lower team match score when normalizing with a minimum confidence; 1 for mapped names
+	ScrapedAt            time.Time         `json:"scraped_at,omitzero"`             // When the listing was parsed, so each source's freshness shows in a merged result
+	Meta                 map[string]string `json:"meta,omitempty"`                  // Source-specific values such as listing IDs; see the Meta* keys
+	RawHTML              string            `json:"raw_html,omitempty"`              // Outer HTML of the listing, only when raw capture is enabled
 }
 
+// EventStatusDateTBD marks a listing whose date hasn't been announced or couldn't be read
+const EventStatusDateTBD = "date_tbd"
+
 // ScrapingResult contains all scraped events and metadata
 type ScrapingResult struct {
 	Events    []TicketEvent `json:"events"`
@@ -17,4 +54,45 @@ type ScrapingResult struct {
 	Timestamp time.Time     `json:"timestamp"`
 	SourceURL string        `json:"source_url"`
 	Source    string        `json:"source"` // "hellotickets" or "vividseats"
+
+	SourceStatus      map[string]string `json:"source_status,omitempty"`      // Per-source outcome of an "all" scrape: ok, error or timeout
+	RawTotal          int               `json:"raw_total,omitempty"`          // Events before deduplication; 0 if no dedup ran
+	DuplicatesRemoved int               `json:"duplicates_removed,omitempty"` // Events collapsed by deduplication
+	Errors            []ScrapeError     `json:"errors,omitempty"`             // Failures of individual sources in an "all" scrape
+	Partial           bool              `json:"partial,omitempty"`            // Some sources were cut off by the time budget
+	ServedBy          string            `json:"served_by,omitempty"`          // Source a "fallback" scrape was answered from
+	Throttled         bool              `json:"throttled,omitempty"`          // The last "all" scrape served again, since it ran within all_min_interval
+	SourceURLs        map[string]string `json:"source_urls,omitempty"`        // URL each source of a merged result was scraped from
+	SkippedCount      int               `json:"skipped_count,omitempty"`      // Listing elements the parsers couldn't turn into events
+	SkipReasons       map[string]int    `json:"skip_reasons,omitempty"`       // Skipped elements by reason, see the SkipReason* constants
+
+	displayNames map[string]string // Source ID to display name for the table, summary and HTML; nil shows IDs
+}
+
+// AddSourceURL records the URL a source of a merged result was scraped from,
+// taking over the part's own per-source URLs when it is itself a merge
+func (r *ScrapingResult) AddSourceURL(part *ScrapingResult) {
+	if len(part.SourceURLs) == 0 && (part.Source == "" || part.SourceURL == "") {
+		return
+	}
+	if r.SourceURLs == nil {
+		r.SourceURLs = make(map[string]string)
+	}
+	if len(part.SourceURLs) > 0 {
+		maps.Copy(r.SourceURLs, part.SourceURLs)
+		return
+	}
+	r.SourceURLs[part.Source] = part.SourceURL
+}
+
+// withEvents returns a copy of the result's metadata holding the given events.
+// A nil slice becomes an empty one so results always marshal "events": [].
+func (r *ScrapingResult) withEvents(events []TicketEvent) *ScrapingResult {
+	if events == nil {
+		events = []TicketEvent{}
+	}
+	derived := *r
+	derived.Events = events
+	derived.Total = len(events)
+	return &derived
 }
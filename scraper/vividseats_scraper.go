@@ -1,43 +1,217 @@
 package scraper
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"golang.org/x/time/rate"
 )
 
 // VividSeatsScraper handles VividSeats web scraping operations
 type VividSeatsScraper struct {
-	collector *colly.Collector
-	baseURL   string
+	collector           *colly.Collector
+	baseURL             string
+	selectors           Selectors
+	language            string
+	captureRaw          bool
+	unexpectedRedirects []string
+	keywords            map[string][]string
+	maxPages            int
+	retryEmpty          bool
+	performers          []string
+	warmup              bool
+	skipWarnRatio       float64
+	credentials         Credentials
+	limiter             *rate.Limiter
+	now                 func() time.Time // Clock for judging listing years, replaced in tests
 }
 
+// DefaultVividSeatsPerformers are the VividSeats performer pages listing Real Madrid matches
+var DefaultVividSeatsPerformers = []string{"3053"}
+
+// vividSeatsPerformerURL returns the Real Madrid listing page of a VividSeats performer ID
+func vividSeatsPerformerURL(id string) string {
+	return "https://www.vividseats.com/real-madrid-tickets--sports-soccer/performer/" + id
+}
+
+// vividSeatsNextPageSelector matches the pagination control linking to the next listing page
+const vividSeatsNextPageSelector = "a[rel='next'], a[aria-label='Next page'], a[data-testid='pagination-next']"
+
 // NewVividSeatsScraper creates a new VividSeats scraper instance
 func NewVividSeatsScraper() *VividSeatsScraper {
+	return NewVividSeatsScraperWithOptions(DefaultScraperOptions())
+}
+
+// NewVividSeatsScraperWithOptions creates a new VividSeats scraper instance with the given options
+func NewVividSeatsScraperWithOptions(opts ScraperOptions) *VividSeatsScraper {
+	// Scrapes reuse the collector's clones, which share its visited-URL store
 	c := colly.NewCollector(
-		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"),
+		colly.UserAgent(opts.userAgent()),
+		colly.AllowURLRevisit(),
 	)
 
 	// Set up rate limiting to be respectful
 	c.Limit(&colly.LimitRule{
 		DomainGlob:  "*",
 		Parallelism: 1,
-		Delay:       1 * time.Second,
+		Delay:       opts.RequestDelay,
 	})
+	c.SetRequestTimeout(opts.Timeout)
+	c.SetRedirectHandler(redirectHandler(opts.maxRedirects(), opts.UnexpectedRedirects))
 
-	return &VividSeatsScraper{
-		collector: c,
-		baseURL:   "https://www.vividseats.com",
+	keywords := opts.ListingTypeKeywords
+	if keywords == nil {
+		keywords = DefaultListingTypeKeywords()
 	}
+
+	performers := opts.VividSeatsPerformers
+	if len(performers) == 0 {
+		performers = DefaultVividSeatsPerformers
+	}
+
+	s := &VividSeatsScraper{
+		collector:           c,
+		baseURL:             "https://www.vividseats.com",
+		selectors:           opts.Selectors.withDefaults(DefaultVividSeatsSelectors()),
+		keywords:            keywords,
+		maxPages:            max(opts.MaxPages, 1),
+		language:            opts.Language,
+		captureRaw:          opts.CaptureRaw,
+		retryEmpty:          opts.RetryEmpty,
+		unexpectedRedirects: opts.UnexpectedRedirects,
+		performers:          performers,
+		warmup:              opts.Warmup,
+		skipWarnRatio:       opts.SkipWarnRatio,
+		credentials:         opts.Credentials,
+		limiter:             opts.Limiter,
+		now:                 time.Now,
+	}
+	s.onRequest(c)
+
+	return s
+}
+
+// onRequest makes every outbound request of c draw from the global request
+// budget and carry the configured language and any caller credentials
+func (s *VividSeatsScraper) onRequest(c *colly.Collector) {
+	c.OnRequest(func(r *colly.Request) {
+		if err := waitLimiter(c.Context, s.limiter); err != nil {
+			r.Abort()
+			return
+		}
+		setLanguage(r.Headers, r.URL, "vividseats", s.language)
+		s.credentials.apply(r.Headers, r.URL, "vividseats")
+	})
+}
+
+// SetWarmup sets whether the homepage is visited before the listings, for sites
+// that only serve them once a first visit has set their cookies
+func (s *VividSeatsScraper) SetWarmup(warmup bool) {
+	s.warmup = warmup
+}
+
+// SetMaxRedirects sets how many redirects a request follows before the last
+// response is used; 0 stops at the first redirect
+func (s *VividSeatsScraper) SetMaxRedirects(n int) {
+	s.collector.SetRedirectHandler(redirectHandler(n, s.unexpectedRedirects))
+}
+
+// SetLanguage sets the Accept-Language sent with every request, e.g. "en" or "es"
+func (s *VividSeatsScraper) SetLanguage(lang string) {
+	s.language = lang
+}
+
+// Name returns the source name, "vividseats"
+func (s *VividSeatsScraper) Name() string {
+	return "vividseats"
+}
+
+// Scrape scrapes the source's performer pages, aborting when ctx is done
+func (s *VividSeatsScraper) Scrape(ctx context.Context) (*ScrapingResult, error) {
+	return s.ScrapeVividSeatsRealMadridTicketsContext(ctx)
 }
 
 // ScrapeVividSeatsRealMadridTickets scrapes the VividSeats Real Madrid tickets page
 func (s *VividSeatsScraper) ScrapeVividSeatsRealMadridTickets() (*ScrapingResult, error) {
-	url := "https://www.vividseats.com/real-madrid-tickets--sports-soccer/performer/3053"
+	return s.ScrapeVividSeatsRealMadridTicketsContext(context.Background())
+}
+
+// ScrapeVividSeatsRealMadridTicketsContext scrapes the VividSeats Real Madrid tickets
+// pages, aborting when ctx is done. Several performer pages are scraped at once
+// and merged by link; the scrape only fails if every page does.
+func (s *VividSeatsScraper) ScrapeVividSeatsRealMadridTicketsContext(ctx context.Context) (*ScrapingResult, error) {
+	// Every performer page's collector shares the cookie jar, so one warm-up covers them all
+	if s.warmup {
+		warm := s.collector.Clone()
+		s.onRequest(warm)
+		warmUp(ctx, warm, "vividseats", s.baseURL+"/")
+	}
+
+	if len(s.performers) == 1 {
+		c := s.collector.Clone()
+		s.onRequest(c)
+		return s.scrapePerformer(ctx, c, vividSeatsPerformerURL(s.performers[0]))
+	}
 
+	results := make([]*ScrapingResult, len(s.performers))
+	errs := make([]error, len(s.performers))
+	var wg sync.WaitGroup
+	for i, performer := range s.performers {
+		// Each page gets its own clone, since the callbacks hold per-page state;
+		// clones share the connections and cookie jar of the long-lived collector
+		c := s.collector.Clone()
+		s.onRequest(c)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = s.scrapePerformer(ctx, c, vividSeatsPerformerURL(performer))
+		}()
+	}
+	wg.Wait()
+
+	merged := &ScrapingResult{
+		Events:    []TicketEvent{},
+		Timestamp: time.Now(),
+		SourceURL: vividSeatsPerformerURL(s.performers[0]),
+		Source:    "vividseats",
+	}
+	seenLinks := make(map[string]bool)
+	failed := 0
+	for i, result := range results {
+		if errs[i] != nil {
+			failed++
+			log.Printf("Skipping VividSeats performer %s: %v", s.performers[i], errs[i])
+			continue
+		}
+		merged.AddSkips(result)
+		// A match listed under several performers is kept once, from the first
+		for _, event := range result.Events {
+			if !seenLinks[event.Link] {
+				seenLinks[event.Link] = true
+				event.OriginalIndex = len(merged.Events)
+				merged.Events = append(merged.Events, event)
+			}
+		}
+	}
+
+	if failed == len(s.performers) {
+		return nil, errors.Join(errs...)
+	}
+
+	merged.Total = len(merged.Events)
+	return merged, nil
+}
+
+// scrapePerformer scrapes one performer's listing pages with c, following pagination
+func (s *VividSeatsScraper) scrapePerformer(ctx context.Context, c *colly.Collector, url string) (*ScrapingResult, error) {
 	result := &ScrapingResult{
 		Events:    []TicketEvent{},
 		Timestamp: time.Now(),
@@ -45,86 +219,210 @@ func (s *VividSeatsScraper) ScrapeVividSeatsRealMadridTickets() (*ScrapingResult
 		Source:    "vividseats",
 	}
 
-	s.collector.OnHTML("div[data-testid*='production-listing']", func(e *colly.HTMLElement) {
-		event := s.parseVividSeatsTicketEvent(e)
-		if event != nil {
+	// Pagination state: pages visited so far, the next-page link found on the
+	// current page and how many new listings it had
+	pages := 1
+	nextPage := ""
+	newOnPage := 0
+	seenLinks := make(map[string]bool)
+
+	c.OnHTML(s.selectors.Listing, func(e *colly.HTMLElement) {
+		event, skipReason := s.parseVividSeatsTicketEvent(e)
+		if event == nil {
+			result.recordSkip(skipReason)
+			return
+		}
+		// The same listing can show up again on a later page
+		if !seenLinks[event.Link] {
+			seenLinks[event.Link] = true
+			newOnPage++
+			event.OriginalIndex = len(result.Events)
 			result.Events = append(result.Events, *event)
 		}
 	})
 
-	s.collector.OnError(func(r *colly.Response, err error) {
+	c.OnHTML(vividSeatsNextPageSelector, func(e *colly.HTMLElement) {
+		if href := e.Attr("href"); href != "" && nextPage == "" {
+			nextPage = e.Request.AbsoluteURL(href)
+		}
+	})
+
+	c.OnScraped(func(r *colly.Response) {
+		target := nextPage
+		// Without a next-page control, keep paging by query param while pages still add listings
+		if target == "" && newOnPage > 0 {
+			target = withPageParam(r.Request.URL, pages+1)
+		}
+		nextPage = ""
+		newOnPage = 0
+
+		if target == "" || pages >= s.maxPages {
+			return
+		}
+		pages++
+		if err := r.Request.Visit(target); err != nil {
+			log.Printf("Stopped VividSeats pagination at page %d: %v", pages, err)
+		}
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
 		log.Printf("Error scraping %s: %v", r.Request.URL, err)
 	})
 
-	err := s.collector.Visit(url)
+	c.Context = ctx
+	err := c.Visit(url)
+	if err != nil {
+		return nil, newFetchError("vividseats", url, fmt.Errorf("failed to visit URL: %w", err))
+	}
+
+	if len(result.Events) == 0 && s.retryEmpty {
+		pages, nextPage, newOnPage = 1, "", 0
+		retryEmptyScrape(ctx, c, "vividseats", url, func() int { return len(result.Events) })
+	}
+
+	warnOnSkips("vividseats", result, s.skipWarnRatio)
+	result.Total = len(result.Events)
+	return result, nil
+}
+
+// ScrapeFromFile runs the VividSeats parser over a saved HTML page instead of the live site
+func (s *VividSeatsScraper) ScrapeFromFile(path string) (*ScrapingResult, error) {
+	result := &ScrapingResult{
+		Events:    []TicketEvent{},
+		Timestamp: time.Now(),
+		SourceURL: path,
+		Source:    "vividseats",
+	}
+
+	err := forEachFixtureElement(path, s.selectors.Listing, func(e *colly.HTMLElement) {
+		event, skipReason := s.parseVividSeatsTicketEvent(e)
+		if event == nil {
+			result.recordSkip(skipReason)
+			return
+		}
+		event.OriginalIndex = len(result.Events)
+		result.Events = append(result.Events, *event)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to visit URL: %w", err)
+		return nil, newScrapeError("vividseats", path, ErrorKindParse, err)
 	}
 
+	warnOnSkips("vividseats", result, s.skipWarnRatio)
 	result.Total = len(result.Events)
 	return result, nil
 }
 
-// parseVividSeatsTicketEvent extracts essential ticket event data from VividSeats HTML element
-func (s *VividSeatsScraper) parseVividSeatsTicketEvent(e *colly.HTMLElement) *TicketEvent {
+// parseVividSeatsTicketEvent extracts essential ticket event data from VividSeats HTML
+// element, returning a nil event and the reason when the element has to be skipped
+func (s *VividSeatsScraper) parseVividSeatsTicketEvent(e *colly.HTMLElement) (*TicketEvent, string) {
 	// Extract link
-	link := e.ChildAttr("a.styles_linkContainer__4li3j", "href")
+	link := e.ChildAttr(s.selectors.Link, "href")
 	if link == "" {
-		return nil
+		return nil, SkipReasonNoLink
 	}
 
 	// Convert to full URL
-	if !strings.HasPrefix(link, "http") {
-		link = s.baseURL + link
-	}
+	link = resolveURL(s.baseURL, link)
 
 	// Extract date information from the left column
-	day := strings.TrimSpace(e.ChildText("div[data-testid='date-time-left-element'] span.MuiTypography-overline"))
-	dateMonth := strings.TrimSpace(e.ChildText("div[data-testid='date-time-left-element'] span.MuiTypography-small-bold"))
-	timeStr := strings.TrimSpace(e.ChildText("div[data-testid='date-time-left-element'] span.MuiTypography-caption"))
+	day := cleanText(e.ChildText(s.selectors.Day))
+	dateMonth := cleanText(e.ChildText(s.selectors.Date))
+	timeStr := cleanText(e.ChildText(s.selectors.Time))
 
 	// Extract event name
-	event := strings.TrimSpace(e.ChildText("span.styles_titleTruncate__XiZ53"))
+	event := cleanText(e.ChildText(s.selectors.Event))
 
 	// Fix date format - separate year from day if they're concatenated
 	formattedDate := s.formatDateWithYear(dateMonth)
 
+	// Multi-day events list a range; DateTime keeps its first day
+	formattedDate, startDate, endDate := splitDateRange(formattedDate)
+	if startDate != "" {
+		day = firstOfRange(day)
+	}
+
 	// Combine date and time into single string
-	datetime := fmt.Sprintf("%s %s %s", formattedDate, day, timeStr)
+	datetime := cleanText(fmt.Sprintf("%s %s %s", formattedDate, day, timeStr))
 
-	return &TicketEvent{
-		DateTime: datetime,
-		Event:    event,
-		Link:     link,
-		Source:   "vividseats",
+	// Extract thumbnail image
+	imageURL := resolveURL(s.baseURL, childImageSrc(e))
+
+	// Extract price when the listing shows one
+	priceText := ""
+	if s.selectors.Price != "" {
+		priceText = strings.TrimSpace(e.ChildText(s.selectors.Price))
 	}
+	priceValue, currency, _ := parsePrice(priceText)
+
+	return &TicketEvent{
+		DateTime:    datetime,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Event:       event,
+		Link:        link,
+		Source:      "vividseats",
+		ImageURL:    imageURL,
+		Price:       priceText,
+		PriceValue:  Price(priceValue),
+		Currency:    currency,
+		Competition: detectCompetition(event),
+		Type:        classifyListing(event, s.keywords), // Parking and hospitality are listed like matches
+		Meta:        eventMeta(MetaVividSeatsProductionID, vividSeatsProductionID(link)),
+		ScrapedAt:   time.Now(),
+		RawHTML:     rawHTML(e.DOM, s.captureRaw),
+	}, ""
+}
+
+// withPageParam returns u with its "page" query parameter set to page
+func withPageParam(u *url.URL, page int) string {
+	next := *u
+	query := next.Query()
+	query.Set("page", strconv.Itoa(page))
+	next.RawQuery = query.Encode()
+	return next.String()
 }
 
-// formatDateWithYear separates the year from the day in date strings like "Jan 182026"
+// formatDateWithYear splits a year glued to the day, e.g. "Jan 182026" becomes
+// "Jan 18 2026". Other tokens, such as a weekday, are kept in place, and input
+// without a glued day and year is returned unchanged.
 func (s *VividSeatsScraper) formatDateWithYear(dateStr string) string {
-	// Look for patterns like "Jan 182026" or "Feb 152026"
-	// Split by spaces and check if the last part has 4+ digits at the end
 	parts := strings.Fields(dateStr)
-	if len(parts) >= 2 {
-		dayPart := parts[1]
-
-		// Check if the day part ends with 4 digits (year)
-		if len(dayPart) >= 5 {
-			// Find where the year starts (last 4 digits)
-			for i := len(dayPart) - 4; i >= 0; i-- {
-				if i+4 <= len(dayPart) {
-					year := dayPart[i : i+4]
-					day := dayPart[:i]
-
-					// Check if year is reasonable (2025-2030)
-					if year == "2025" || year == "2026" || year == "2027" || year == "2028" || year == "2029" || year == "2030" {
-						return fmt.Sprintf("%s %s %s", parts[0], day, year)
-					}
-				}
-			}
+	changed := false
+	thisYear := s.now().Year()
+
+	// The day follows the month, so the first token can't be one
+	for i := 1; i < len(parts); i++ {
+		if day, year, ok := splitDayYear(parts[i], thisYear); ok {
+			parts[i] = day + " " + year
+			changed = true
 		}
 	}
 
-	// If no year found, return as is
-	return dateStr
+	if !changed {
+		return dateStr
+	}
+	return strings.Join(parts, " ")
+}
+
+// splitDayYear splits a token of one or two day digits followed by a four-digit
+// year, e.g. "182026", reporting false for anything else. Listings are for
+// upcoming events, so the year must be close to thisYear.
+func splitDayYear(token string, thisYear int) (day, year string, ok bool) {
+	if len(token) < 5 || len(token) > 6 {
+		return "", "", false
+	}
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			return "", "", false
+		}
+	}
+
+	day, year = token[:len(token)-4], token[len(token)-4:]
+	dayNum, _ := strconv.Atoi(day)
+	yearNum, _ := strconv.Atoi(year)
+
+	if dayNum < 1 || dayNum > 31 || yearNum < thisYear-1 || yearNum > thisYear+5 {
+		return "", "", false
+	}
+	return day, year, true
 }
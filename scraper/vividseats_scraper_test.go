@@ -0,0 +1,182 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// vividListing renders one VividSeats listing as the default selectors expect it
+func vividListing(link, title, date, day, timeStr string) string {
+	return fmt.Sprintf(`<div data-testid="production-listing-1">
+<a class="styles_linkContainer__4li3j" href="%s">
+<div data-testid="date-time-left-element">
+<span class="MuiTypography-small-bold">%s</span>
+<span class="MuiTypography-overline">%s</span>
+<span class="MuiTypography-caption">%s</span>
+</div>
+<span class="styles_titleTruncate__XiZ53">%s</span>
+</a>
+</div>`, link, date, day, timeStr, title)
+}
+
+// vividPage wraps listings in a page, with a next-page control when next is set
+func vividPage(next string, listings ...string) string {
+	var b strings.Builder
+	b.WriteString("<html><body>")
+	for _, l := range listings {
+		b.WriteString(l)
+	}
+	if next != "" {
+		fmt.Fprintf(&b, `<a rel="next" href="%s">Next</a>`, next)
+	}
+	b.WriteString("</body></html>")
+	return b.String()
+}
+
+func TestVividSeatsPagination(t *testing.T) {
+	pages := map[string]string{
+		"": vividPage("?page=2",
+			vividListing("/real-madrid-vs-getafe/production/1", "Real Madrid vs Getafe", "Sep 2", "Sat", "9:00pm"),
+			vividListing("/real-madrid-vs-alaves/production/2", "Real Madrid vs Alaves", "Sep 16", "Sat", "4:15pm"),
+		),
+		"2": vividPage("",
+			// The last listing of page one shows up again on page two
+			vividListing("/real-madrid-vs-alaves/production/2", "Real Madrid vs Alaves", "Sep 16", "Sat", "4:15pm"),
+			vividListing("/real-madrid-vs-sevilla/production/3", "Real Madrid vs Sevilla", "Oct 7", "Sat", "9:00pm"),
+		),
+	}
+
+	for _, tc := range []struct {
+		maxPages int
+		want     []string
+	}{
+		{1, []string{"/production/1", "/production/2"}},
+		{2, []string{"/production/1", "/production/2", "/production/3"}},
+		{5, []string{"/production/1", "/production/2", "/production/3"}},
+	} {
+		t.Run(fmt.Sprintf("maxPages=%d", tc.maxPages), func(t *testing.T) {
+			var requested []string
+			opts := testOptions()
+			opts.MaxPages = tc.maxPages
+			opts.VividSeatsPerformers = []string{"11111"}
+			s := NewVividSeatsScraperWithOptions(opts)
+			mockSite(t, s.collector, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page := r.URL.Query().Get("page")
+				requested = append(requested, page)
+				body, ok := pages[page]
+				if !ok {
+					// Past the last page the site repeats nothing new
+					body = vividPage("")
+				}
+				w.Write([]byte(body))
+			}))
+
+			result, err := s.Scrape(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Total != len(tc.want) {
+				t.Fatalf("got %d events, want %d: %+v", result.Total, len(tc.want), result.Events)
+			}
+			for i, suffix := range tc.want {
+				if !strings.HasSuffix(result.Events[i].Link, suffix) {
+					t.Errorf("event %d link = %q, want suffix %q", i, result.Events[i].Link, suffix)
+				}
+				if result.Events[i].OriginalIndex != i {
+					t.Errorf("event %d has OriginalIndex %d", i, result.Events[i].OriginalIndex)
+				}
+			}
+			if len(requested) > tc.maxPages {
+				t.Errorf("requested %d pages, want at most %d", len(requested), tc.maxPages)
+			}
+		})
+	}
+}
+
+func TestFormatDateWithYear(t *testing.T) {
+	s := &VividSeatsScraper{now: func() time.Time { return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC) }}
+
+	for _, tc := range []struct {
+		name, in, want string
+	}{
+		{"empty", "", ""},
+		{"single field", "Jan", "Jan"},
+		{"day without year", "Jan 1", "Jan 1"},
+		{"normal", "Jan 182026", "Jan 18 2026"},
+		{"single-digit day", "Feb 52027", "Feb 5 2027"},
+		{"weekday kept", "Sat Jan 182026", "Sat Jan 18 2026"},
+		{"extra spaces", "Jan  182026 ", "Jan 18 2026"},
+		{"year too far", "Jan 182040", "Jan 182040"},
+		{"year in the past", "Jan 182020", "Jan 182020"},
+		{"day out of range", "Jan 322026", "Jan 322026"},
+		{"not digits", "Jan 18x026", "Jan 18x026"},
+		{"too long", "Jan 1182026", "Jan 1182026"},
+		{"month-only digits", "182026", "182026"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := s.formatDateWithYear(tc.in); got != tc.want {
+				t.Errorf("formatDateWithYear(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitDayYearFollowsClock(t *testing.T) {
+	// The same token is a plausible listing year in 2026 but not in 2040
+	if _, _, ok := splitDayYear("182027", 2026); !ok {
+		t.Error("182027 should split in 2026")
+	}
+	if _, _, ok := splitDayYear("182027", 2040); ok {
+		t.Error("182027 should not split in 2040")
+	}
+}
+
+func TestVividSeatsMergesPerformers(t *testing.T) {
+	pages := map[string]string{
+		"/performer/111": vividPage("",
+			vividListing("/real-madrid-vs-getafe/production/1", "Real Madrid vs Getafe", "Sep 2", "Sat", "9:00pm"),
+			vividListing("/real-madrid-vs-city/production/2", "Real Madrid vs Manchester City", "Sep 17", "Wed", "9:00pm"),
+		),
+		"/performer/222": vividPage("",
+			// Champions League matches are also listed under the club's own page
+			vividListing("/real-madrid-vs-city/production/2", "Real Madrid vs Manchester City", "Sep 17", "Wed", "9:00pm"),
+			vividListing("/real-madrid-vs-juventus/production/3", "Real Madrid vs Juventus", "Oct 22", "Wed", "9:00pm"),
+		),
+	}
+
+	opts := testOptions()
+	opts.MaxPages = 1
+	opts.VividSeatsPerformers = []string{"111", "222", "333"}
+	s := NewVividSeatsScraperWithOptions(opts)
+	mockSite(t, s.collector, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for suffix, body := range pages {
+			if strings.HasSuffix(r.URL.Path, suffix) {
+				w.Write([]byte(body))
+				return
+			}
+		}
+		// A failing page is skipped rather than failing the scrape
+		http.Error(w, "gone", http.StatusNotFound)
+	}))
+
+	result, err := s.Scrape(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/production/1", "/production/2", "/production/3"}
+	if result.Total != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", result.Total, len(want), result.Events)
+	}
+	for i, suffix := range want {
+		if !strings.HasSuffix(result.Events[i].Link, suffix) {
+			t.Errorf("event %d link = %q, want suffix %q", i, result.Events[i].Link, suffix)
+		}
+		if result.Events[i].OriginalIndex != i {
+			t.Errorf("event %d has OriginalIndex %d", i, result.Events[i].OriginalIndex)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package scraper
+
+import (
+	"context"
+	"log"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// warmUp visits the site's homepage with c before the listing is scraped, so
+// anti-bot cookies set on a first visit are sent with the listing request. c
+// should be a clone without the listing callbacks; clones share the cookie jar.
+// Failures are logged, leaving the listing request to report any block.
+func warmUp(ctx context.Context, c *colly.Collector, source, homeURL string) {
+	c.Context = ctx
+	if err := c.Visit(homeURL); err != nil {
+		log.Printf("%s: warm-up visit to %s failed: %v", source, homeURL, err)
+	}
+}
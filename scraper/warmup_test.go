@@ -0,0 +1,51 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// challengeSite sets a clearance cookie on the homepage and answers 403 to any
+// other page requested without it, like a site's first-visit bot check
+func challengeSite(page string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			http.SetCookie(w, &http.Cookie{Name: "clearance", Value: "ok", Path: "/"})
+			w.Write([]byte("<html><body>home</body></html>"))
+			return
+		}
+		if cookie, err := r.Cookie("clearance"); err != nil || cookie.Value != "ok" {
+			http.Error(w, "challenge", http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(page))
+	}
+}
+
+func TestWarmupSetsCookies(t *testing.T) {
+	helloPage := "<html><body><ul>" + helloListing("/atletico/2263527/2", "Atlético de Madrid vs. Real Madrid CF", "27 Sep", "Sat", "4:15pm") + "</ul></body></html>"
+	vividPage := vividPage("", vividListing("/real-madrid-vs-getafe/production/1", "Real Madrid vs Getafe", "Sep 2", "Sat", "9:00pm"))
+
+	for _, warmup := range []bool{false, true} {
+		opts := testOptions()
+		opts.Warmup = warmup
+		opts.VividSeatsPerformers = []string{"11111"}
+
+		hello := NewScraperWithOptions(testOptions())
+		hello.SetWarmup(warmup)
+		mockSite(t, hello.collector, challengeSite(helloPage))
+		vivid := NewVividSeatsScraperWithOptions(opts)
+		mockSite(t, vivid.collector, challengeSite(vividPage))
+
+		for _, s := range []SourceScraper{hello, vivid} {
+			result, err := s.Scrape(context.Background())
+			if warmup && (err != nil || result.Total != 1) {
+				t.Errorf("%s with warm-up: got %+v, %v; want the listing", s.Name(), result, err)
+			}
+			if !warmup && err == nil && result.Total != 0 {
+				t.Errorf("%s without warm-up got past the challenge: %+v", s.Name(), result.Events)
+			}
+		}
+	}
+}
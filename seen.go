@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"normalizer/scraper"
+)
+
+// seenRegistry remembers which listings have been seen and when, so new
+// listings can be reported. With a file path it survives restarts; without
+// one it lives in memory only.
+type seenRegistry struct {
+	mu   sync.Mutex
+	path string
+	seen map[string]time.Time // Listing link -> first seen
+}
+
+// newSeenRegistry creates a registry, loading previously seen listings from path if it exists
+func newSeenRegistry(path string) (*seenRegistry, error) {
+	registry := &seenRegistry{path: path, seen: make(map[string]time.Time)}
+	if path == "" {
+		return registry, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seen file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &registry.seen); err != nil {
+		return nil, fmt.Errorf("failed to parse seen file %s: %w", path, err)
+	}
+	return registry, nil
+}
+
+// markSeen records the events and returns the ones not seen before, in order
+func (r *seenRegistry) markSeen(events []scraper.TicketEvent, now time.Time) ([]scraper.TicketEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fresh := []scraper.TicketEvent{}
+	for _, event := range events {
+		id := scraper.IdentityByLink(event)
+		if id == "" {
+			continue
+		}
+		if _, exists := r.seen[id]; exists {
+			continue
+		}
+		r.seen[id] = now
+		fresh = append(fresh, event)
+	}
+
+	if len(fresh) > 0 {
+		if err := r.save(); err != nil {
+			return fresh, err
+		}
+	}
+	return fresh, nil
+}
+
+// save writes the registry to disk, replacing the file atomically so a crash
+// never leaves it half-written. Callers must hold r.mu.
+func (r *seenRegistry) save() error {
+	if r.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.seen, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal seen listings: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to write seen file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write seen file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write seen file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("failed to replace seen file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"normalizer/scraper"
+)
+
+// streamKeepAlive is how often an idle event stream sends a comment, so
+// proxies don't close the connection
+const streamKeepAlive = 30 * time.Second
+
+// eventStreams hands the changes found by each watchlist refresh to the
+// clients following that source
+type eventStreams struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan scraper.DiffResult]bool
+	last        map[string]*scraper.ScrapingResult // Latest refresh of each source, to diff the next one against
+}
+
+// newEventStreams creates an empty set of event streams
+func newEventStreams() *eventStreams {
+	return &eventStreams{
+		subscribers: make(map[string]map[chan scraper.DiffResult]bool),
+		last:        make(map[string]*scraper.ScrapingResult),
+	}
+}
+
+// subscribe registers a client for the changes of a source
+func (s *eventStreams) subscribe(source string) chan scraper.DiffResult {
+	ch := make(chan scraper.DiffResult, 8)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscribers[source] == nil {
+		s.subscribers[source] = make(map[chan scraper.DiffResult]bool)
+	}
+	s.subscribers[source][ch] = true
+	return ch
+}
+
+// unsubscribe removes a client, e.g. once it disconnects
+func (s *eventStreams) unsubscribe(source string, ch chan scraper.DiffResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers[source], ch)
+}
+
+// publish diffs a source's new result against its previous one and sends any
+// added, removed or changed listings to the source's subscribers. The first
+// result of a source only sets the baseline.
+func (s *eventStreams) publish(source string, result *scraper.ScrapingResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous := s.last[source]
+	s.last[source] = result
+	if previous == nil || previous == result {
+		return
+	}
+
+	diff := scraper.Diff(previous, result, scraper.IdentityByLink)
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+		return
+	}
+
+	for ch := range s.subscribers[source] {
+		select {
+		case ch <- diff:
+		default:
+			// A client this far behind misses the update rather than stalling the scheduler
+			log.Printf("Dropped %s changes for a slow event stream client", source)
+		}
+	}
+}
+
+// handleEventStream sends the listings changed by each watchlist refresh of a
+// source as Server-Sent Events, until the client disconnects
+func (ws *WebServer) handleEventStream(w http.ResponseWriter, r *http.Request) {
+	source := r.URL.Query().Get("source")
+	if source == "" {
+		source = ws.config.DefaultSource
+	}
+
+	if ws.watchlist == nil {
+		http.Error(w, "Event streams follow the watchlist refreshes; start the server with -watchlist", http.StatusServiceUnavailable)
+		return
+	}
+	if _, teams := ws.watchedTeams(); len(teams[source]) == 0 {
+		http.Error(w, fmt.Sprintf("Source %s is not on the watchlist", source), http.StatusBadRequest)
+		return
+	}
+
+	changes := ws.streams.subscribe(source)
+	defer ws.streams.unsubscribe(source, changes)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	flusher := http.NewResponseController(w)
+	if err := flusher.Flush(); err != nil {
+		log.Printf("Event stream can't be flushed: %v", err)
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case diff := <-changes:
+			data, err := json.Marshal(diff)
+			if err != nil {
+				log.Printf("Failed to encode %s changes: %v", source, err)
+				continue
+			}
+			fmt.Fprintf(w, "event: changes\ndata: %s\n\n", data)
+		}
+		if err := flusher.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build metadata, set at build time with e.g.
+// go build -ldflags "-X main.version=1.2.0 -X main.commit=$(git rev-parse HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	version   = "1.0.0"
+	commit    = ""
+	buildDate = ""
+)
+
+// buildInfo describes the running binary
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"build_date,omitempty"`
+	GoVersion string `json:"go_version"`
+	Modified  bool   `json:"modified,omitempty"` // Built from a working tree with uncommitted changes
+}
+
+// currentBuildInfo combines the ldflags values with the VCS details Go embeds
+// in the binary, which fill in the commit and date when ldflags weren't given
+func currentBuildInfo() buildInfo {
+	info := buildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	embedded, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range embedded.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if info.BuildDate == "" {
+				info.BuildDate = setting.Value
+			}
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	return info
+}
+
+// handleVersion returns the build metadata of the running server
+func (ws *WebServer) handleVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(currentBuildInfo())
+}
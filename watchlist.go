@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"normalizer/scraper"
+)
+
+// watchEntry is one source/team pair the scheduler keeps fresh
+type watchEntry struct {
+	Source string `json:"source"`
+	Team   string `json:"team"`
+}
+
+// watchlist is the set of source/team pairs loaded from the watchlist file
+type watchlist struct {
+	mu      sync.RWMutex
+	path    string
+	entries []watchEntry
+}
+
+// loadWatchlistFile reads "source team" pairs, one per line. Blank lines and
+// lines starting with # are ignored.
+func (ws *WebServer) loadWatchlistFile(path string) ([]watchEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open watchlist: %w", err)
+	}
+	defer f.Close()
+
+	var entries []watchEntry
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("watchlist line %d: expected \"source team\", got %q", lineNum, line)
+		}
+		source, team := fields[0], fields[1]
+		if !scraper.IsSource(source) && source != "all" {
+			return nil, fmt.Errorf("watchlist line %d: unknown source %q", lineNum, source)
+		}
+		if _, ok := ws.normalizer.ResolveTeamSlug(team); !ok {
+			return nil, fmt.Errorf("watchlist line %d: unknown team %q", lineNum, team)
+		}
+
+		entries = append(entries, watchEntry{Source: source, Team: team})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read watchlist: %w", err)
+	}
+
+	return entries, nil
+}
+
+// reloadWatchlist re-reads the watchlist file, keeping the current list if the file is invalid
+func (ws *WebServer) reloadWatchlist() error {
+	entries, err := ws.loadWatchlistFile(ws.watchlist.path)
+	if err != nil {
+		return err
+	}
+
+	ws.watchlist.mu.Lock()
+	ws.watchlist.entries = entries
+	ws.watchlist.mu.Unlock()
+	return nil
+}
+
+// watchEntries returns a copy of the current watchlist
+func (ws *WebServer) watchEntries() []watchEntry {
+	ws.watchlist.mu.RLock()
+	defer ws.watchlist.mu.RUnlock()
+	return append([]watchEntry{}, ws.watchlist.entries...)
+}
+
+// runWatchlist refreshes every watched source each interval and reloads the
+// watchlist file on SIGHUP, until ctx is done
+func (ws *WebServer) runWatchlist(ctx context.Context) {
+	if ws.config.CacheTTL.Duration <= 0 {
+		log.Printf("Warning: the watchlist scheduler refreshes the cache, but caching is disabled (cache_ttl is 0)")
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(ws.config.WatchInterval.Duration)
+	defer ticker.Stop()
+
+	ws.refreshWatchlist(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Stopped the watchlist scheduler: %v", ctx.Err())
+			return
+		case <-hup:
+			if err := ws.reloadWatchlist(); err != nil {
+				log.Printf("Failed to reload watchlist, keeping the current one: %v", err)
+				continue
+			}
+			log.Printf("Reloaded watchlist from %s (%d entries)", ws.watchlist.path, len(ws.watchEntries()))
+		case <-ticker.C:
+			ws.refreshWatchlist(ctx)
+		}
+	}
+}
+
+// watchedTeams returns the canonical teams watched on each source, with the
+// sources in watchlist order. An "all" entry watches its team on every
+// registered source.
+func (ws *WebServer) watchedTeams() ([]string, map[string][]string) {
+	var sources []string
+	teams := make(map[string][]string)
+	for _, entry := range ws.watchEntries() {
+		canonical, ok := ws.normalizer.ResolveTeamSlug(entry.Team)
+		if !ok {
+			continue // Checked when the file was loaded
+		}
+
+		entrySources := []string{entry.Source}
+		if entry.Source == "all" {
+			entrySources = scraper.SourceNames()
+		}
+		for _, source := range entrySources {
+			if _, seen := teams[source]; !seen {
+				sources = append(sources, source)
+			}
+			if !slices.Contains(teams[source], canonical) {
+				teams[source] = append(teams[source], canonical)
+			}
+		}
+	}
+	return sources, teams
+}
+
+// refreshWatchlist scrapes each watched source once and publishes the
+// listings of its watched teams, so teams sharing a source share one scrape.
+// It always scrapes, however fresh the cache is, and stores the result in the
+// cache, so API readers see the data the streams were sent.
+func (ws *WebServer) refreshWatchlist(ctx context.Context) {
+	sources, teams := ws.watchedTeams()
+	for _, source := range sources {
+		if ctx.Err() != nil {
+			return
+		}
+
+		result, err := ws.scrapeSource(ctx, source)
+		if err != nil {
+			log.Printf("Watchlist refresh of %s failed: %v", source, err)
+			continue
+		}
+		ws.storeCached(source, result)
+		ws.streams.publish(source, ws.normalizer.FilterByTeams(result, teams[source]...))
+	}
+}
+
+// handleWatchlist returns the current watchlist
+func (ws *WebServer) handleWatchlist(w http.ResponseWriter, r *http.Request) {
+	entries := []watchEntry{}
+	if ws.watchlist != nil {
+		entries = ws.watchEntries()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
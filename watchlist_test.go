@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"normalizer/scraper"
+)
+
+// helloTicketsPage is a saved HelloTickets listing page with one Real Madrid
+// match and one match of two other teams
+const helloTicketsPage = `<html><body><ul>
+<li class="performance performances-list__item"><a class="performance__link" href="/real-madrid-getafe/1/2">
+<div class="performance__date-month">27 Sep</div><div class="performance__date-day"><p>Sat</p><p>4:15pm</p></div>
+<span class="performance__description__name">Real Madrid CF vs. Getafe CF</span></a></li>
+<li class="performance performances-list__item"><a class="performance__link" href="/sevilla-betis/2/2">
+<div class="performance__date-month">28 Sep</div><div class="performance__date-day"><p>Sun</p><p>9:00pm</p></div>
+<span class="performance__description__name">Sevilla FC vs. Real Betis</span></a></li>
+</ul></body></html>`
+
+// newWatchlistServer creates a server reading sources from saved pages and
+// watching the given watchlist file content
+func newWatchlistServer(t *testing.T, watchlist string) *WebServer {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hellotickets.html"), []byte(helloTicketsPage), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ws, _ := newTestServer(t, func(c *Config) {
+		c.OfflineDir = dir
+		c.WatchlistFile = writeConfig(t, "watchlist.txt", watchlist)
+	})
+	return ws
+}
+
+func TestWatchlistPublishesWatchedTeamsOnly(t *testing.T) {
+	ws := newWatchlistServer(t, "hellotickets real-madrid\n")
+
+	ws.refreshWatchlist(context.Background())
+
+	published := ws.streams.last["hellotickets"]
+	if published == nil || len(published.Events) != 1 {
+		t.Fatalf("published %+v, want the one Real Madrid event", published)
+	}
+	if !strings.Contains(published.Events[0].Event, "Real Madrid") {
+		t.Errorf("published %q, want the Real Madrid match", published.Events[0].Event)
+	}
+}
+
+func TestWatchlistRefreshBypassesCache(t *testing.T) {
+	ws, _ := newTestServer(t, func(c *Config) {
+		c.CacheTTL = Duration{time.Hour}
+		c.WatchlistFile = writeConfig(t, "watchlist.txt", "hellotickets real-madrid\n")
+	})
+	stub := &stubScraper{name: "hellotickets", events: []scraper.TicketEvent{
+		{Event: "Real Madrid CF vs. Getafe CF", Link: "https://hellotickets.example/1", Source: "hellotickets"},
+	}}
+	useScrapers(ws, stub)
+
+	// A fresh cache entry from before the match went on sale
+	stale := &scraper.ScrapingResult{Events: []scraper.TicketEvent{}, Source: "hellotickets"}
+	ws.cache["hellotickets"] = cacheEntry{result: stale, expires: time.Now().Add(time.Hour)}
+
+	ws.refreshWatchlist(context.Background())
+
+	if got := stub.calls.Load(); got != 1 {
+		t.Fatalf("source scraped %d times, want 1", got)
+	}
+	if published := ws.streams.last["hellotickets"]; published == nil || len(published.Events) != 1 {
+		t.Errorf("published %+v, want the new Real Madrid event", published)
+	}
+	cached, err := ws.scrapeCached(context.Background(), "hellotickets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.Total != 1 {
+		t.Errorf("cache holds %d events after the refresh, want the new one", cached.Total)
+	}
+	if got := stub.calls.Load(); got != 1 {
+		t.Errorf("source scraped %d times, want the API read served from the cache", got)
+	}
+}
+
+func TestWatchlistAllWatchesEverySource(t *testing.T) {
+	ws := newWatchlistServer(t, "all real-madrid\nhellotickets barcelona\n")
+
+	sources, teams := ws.watchedTeams()
+	if !slices.Contains(sources, "hellotickets") || !slices.Contains(sources, "vividseats") || !slices.Contains(sources, "sport365") {
+		t.Errorf("watched sources = %v, want every registered source", sources)
+	}
+	if got := teams["hellotickets"]; !slices.Equal(got, []string{"Real Madrid", "Barcelona"}) {
+		t.Errorf("hellotickets teams = %v", got)
+	}
+	if got := teams["vividseats"]; !slices.Equal(got, []string{"Real Madrid"}) {
+		t.Errorf("vividseats teams = %v", got)
+	}
+}
+
+func TestWatchlistStopsWithContext(t *testing.T) {
+	ws := newWatchlistServer(t, "hellotickets real-madrid\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ws.runWatchlist(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("runWatchlist kept running after its context was cancelled")
+	}
+}
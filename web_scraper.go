@@ -1,43 +1,225 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"normalizer/scraper"
 
 	"github.com/gorilla/mux"
+	"golang.org/x/time/rate"
 )
 
 // WebServer handles HTTP requests for the web interface
 type WebServer struct {
-	scraper *scraper.Scraper
-	port    string
+	normalizer *scraper.TeamNameNormalizer
+	config     Config
+	location   *time.Location
+	sourceLoc  *time.Location
+	limiter    *rate.Limiter
+	seen       *seenRegistry
+	jobs       *jobStore
+	watchlist  *watchlist // nil unless a watchlist file is configured
+	streams    *eventStreams
+	port       string
+
+	matchPattern *regexp.Regexp // What a fixture's name looks like, for matchesOnly
+
+	// Long-lived scrapers by source, reused by every request so their connections
+	// and cookies persist. Each is built from the registry on its first scrape.
+	scrapersMu sync.Mutex
+	scrapers   map[string]scraper.SourceScraper
+
+	cacheMu    sync.Mutex
+	cache      map[string]cacheEntry
+	normalized map[string]normalizedEntry
+
+	allMu     sync.Mutex
+	lastAll   *scraper.ScrapingResult // Last real "all" scrape, served again within all_min_interval
+	lastAllAt time.Time
+	allRun    *allScrape // The throttled "all" scrape in progress, if any
+}
+
+// allScrape is an "all" scrape in progress; done is closed once result and err are set
+type allScrape struct {
+	done   chan struct{}
+	result *scraper.ScrapingResult
+	err    error
 }
 
-// NewWebServer creates a new web server instance
-func NewWebServer(port string) *WebServer {
-	return &WebServer{
-		scraper: scraper.NewScraper(),
-		port:    port,
+// cacheEntry is a cached raw scrape result
+type cacheEntry struct {
+	result  *scraper.ScrapingResult
+	expires time.Time
+}
+
+// normalizedEntry is a normalized copy of the cached raw result it was derived
+// from, before any minimum confidence is applied
+type normalizedEntry struct {
+	raw    *scraper.ScrapingResult
+	result *scraper.ScrapingResult
+}
+
+// errInvalidSource is returned when a request names an unknown source
+var errInvalidSource = errors.New("invalid source")
+
+// invalidSourceMessage tells the caller which sources are registered
+func invalidSourceMessage() string {
+	return fmt.Sprintf("Invalid source. Use: %s, all, or fallback", strings.Join(scraper.SourceNames(), ", "))
+}
+
+// NewWebServer creates a new web server instance from the given config
+func NewWebServer(config Config) (*WebServer, error) {
+	location, err := time.LoadLocation(config.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", config.Timezone, err)
+	}
+	sourceLoc, err := time.LoadLocation(config.SourceTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source timezone %q: %w", config.SourceTimezone, err)
 	}
+
+	// Load team name mappings
+	normalizerOpts := scraper.NormalizerOptions{
+		Strict:        config.StrictMappings,
+		NationalTeams: config.NationalTeams,
+		SimilarityGap: &config.SimilarityGap,
+	}
+	normalizer := scraper.NewTeamNameNormalizerWithOptions(normalizerOpts)
+	if config.MappingsFile != "" {
+		normalizer, err = scraper.NewTeamNameNormalizerFromFile(config.MappingsFile, normalizerOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load team mappings: %w", err)
+		}
+		fmt.Printf("Loaded team mappings from %s\n", config.MappingsFile)
+	}
+
+	// Chrome is shared by every Sport365 scrape in the process, so its limit is process-wide
+	scraper.SetMaxBrowsers(config.MaxBrowsers)
+
+	seen, err := newSeenRegistry(config.SeenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &WebServer{
+		normalizer: normalizer,
+		config:     config,
+		location:   location,
+		sourceLoc:  sourceLoc,
+		limiter:    scraper.NewRequestLimiter(config.GlobalRPM, config.GlobalBurst),
+		seen:       seen,
+		jobs:       newJobStore(),
+		streams:    newEventStreams(),
+		port:       config.Port,
+		scrapers:   make(map[string]scraper.SourceScraper),
+		cache:      make(map[string]cacheEntry),
+		normalized: make(map[string]normalizedEntry),
+	}
+	ws.matchPattern, err = regexp.Compile(config.MatchPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid match_pattern: %w", err)
+	}
+	if config.WatchlistFile != "" {
+		ws.watchlist = &watchlist{path: config.WatchlistFile}
+		if err := ws.reloadWatchlist(); err != nil {
+			return nil, err
+		}
+	}
+
+	return ws, nil
 }
 
-// Start starts the web server
+// Start starts the web server and serves until the process is interrupted,
+// then stops the background work and finishes the requests in flight
 func (ws *WebServer) Start() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{Addr: ":" + ws.port, Handler: ws.router()}
+	ws.logEndpoints()
+
+	// Keep the watched sources fresh in the background
+	if ws.watchlist != nil {
+		go ws.runWatchlist(ctx)
+	}
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-ctx.Done()
+		log.Printf("Shutting down")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Shutdown did not finish cleanly: %v", err)
+		}
+	}()
+
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-shutdownDone
+
+	// Don't leave Chrome running once the requests using it are done
+	scraper.CloseBrowsers()
+	return nil
+}
+
+// shutdownTimeout is how long requests in flight get to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// router returns the API routes with their middleware
+func (ws *WebServer) router() *mux.Router {
 	r := mux.NewRouter()
 
 	// API routes (no prefix)
 	r.HandleFunc("/scrape", ws.handleScrape).Methods("GET")
+	r.HandleFunc("/scrape", ws.handleScrapeWithCredentials).Methods("POST")
+	r.HandleFunc("/scrape/batch", ws.handleBatch).Methods("POST")
+	r.HandleFunc("/scrape/custom", ws.handleCustomScrape).Methods("POST")
+	r.HandleFunc("/scrape/new", ws.handleNew).Methods("GET")
+	r.HandleFunc("/event", ws.handleEvent).Methods("GET")
+	r.HandleFunc("/jobs", ws.handleCreateJob).Methods("POST")
+	r.HandleFunc("/jobs/{id}", ws.handleGetJob).Methods("GET")
+	r.HandleFunc("/jobs/{id}", ws.handleCancelJob).Methods("DELETE")
+	r.HandleFunc("/stats", ws.handleStats).Methods("GET")
+	r.HandleFunc("/stats/prices", ws.handlePriceStats).Methods("GET")
+	r.HandleFunc("/watchlist", ws.handleWatchlist).Methods("GET")
+	r.HandleFunc("/events/stream", ws.handleEventStream).Methods("GET")
+	r.HandleFunc("/sources", ws.handleSources).Methods("GET")
 	r.HandleFunc("/health", ws.handleHealth).Methods("GET")
+	r.HandleFunc("/version", ws.handleVersion).Methods("GET")
 
 	// Handle OPTIONS requests for CORS
 	r.HandleFunc("/scrape", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/scrape/batch", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/scrape/custom", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/scrape/new", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/event", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/jobs", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/jobs/{id}", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/stats", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/stats/prices", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/watchlist", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/events/stream", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/sources", ws.handleOptions).Methods("OPTIONS")
 	r.HandleFunc("/health", ws.handleOptions).Methods("OPTIONS")
+	r.HandleFunc("/version", ws.handleOptions).Methods("OPTIONS")
 
 	// API-only mode - no static file serving
 
@@ -45,14 +227,31 @@ func (ws *WebServer) Start() error {
 	r.Use(corsMiddleware)
 
 	// Logging middleware
-	r.Use(loggingMiddleware)
+	r.Use(loggingMiddleware(ws.config.LogFormat == "json", ws.config.RedactParams))
+
+	return r
+}
 
+// logEndpoints prints the address and the available endpoints
+func (ws *WebServer) logEndpoints() {
 	fmt.Printf("🚀 API server starting on http://localhost:%s\n", ws.port)
 	fmt.Printf("🔗 Available endpoints:\n")
 	fmt.Printf("   - GET /scrape - Scrape tickets\n")
+	fmt.Printf("   - POST /scrape - Scrape one source with your own cookies or basic auth\n")
+	fmt.Printf("   - POST /scrape/batch - Run several scrape jobs at once\n")
+	fmt.Printf("   - POST /scrape/custom - Scrape any page with your own selectors\n")
+	fmt.Printf("   - GET /scrape/new - Listings not seen in earlier scrapes\n")
+	fmt.Printf("   - GET /event - Details of a single match page\n")
+	fmt.Printf("   - POST /jobs - Start a batch of scrape jobs in the background\n")
+	fmt.Printf("   - GET /jobs/{id} - Job status, progress and results\n")
+	fmt.Printf("   - DELETE /jobs/{id} - Cancel a running job\n")
+	fmt.Printf("   - GET /stats - Scrape statistics and suggested refresh interval\n")
+	fmt.Printf("   - GET /stats/prices - Min, max, average and median listing prices\n")
+	fmt.Printf("   - GET /watchlist - Source/team pairs refreshed in the background\n")
+	fmt.Printf("   - GET /events/stream - Server-Sent Events of listings changed by watchlist refreshes\n")
+	fmt.Printf("   - GET /sources - Sources and the event fields each one provides\n")
 	fmt.Printf("   - GET /health - Health check\n")
-
-	return http.ListenAndServe(":"+ws.port, r)
+	fmt.Printf("   - GET /version - Build version, commit and Go version\n")
 }
 
 // handleScrape handles the scraping API endpoint
@@ -61,108 +260,771 @@ func (ws *WebServer) handleScrape(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	source := query.Get("source")
 	if source == "" {
-		source = "hellotickets"
+		source = ws.config.DefaultSource
 	}
 
 	normalize := query.Get("normalize") == "true"
-	filter := query.Get("filter")
-	dateFrom := query.Get("from")
-	dateTo := query.Get("to")
+	cleanLinks := query.Get("cleanlinks") == "true"
+	dedupFuzzy := query.Get("dedupFuzzy") == "true"
+	dedup := query.Get("dedup") == "true" || dedupFuzzy
+	collapseListings := query.Get("collapseListings") == "true"
+	failEmpty := query.Get("failEmpty") == "true"
+	lang := query.Get("lang")
+	includeRound := query.Get("includeRound") == "true"
+	team := query.Get("team")
+	displayTz := query.Get("displayTz")
+	dateFormat := query.Get("dateFormat")
+	pretty := query.Get("pretty") == "true"
+	priceAsString := query.Get("priceAsString") == "true"
+	displayNames := query.Get("displayNames") == "true"
+	format := query.Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	if format != "json" && format != "rss" && format != "html" && format != "links" && format != "compact" && format != "tsv" && format != "protobuf" && format != "reminders" {
+		http.Error(w, "Invalid format. Use: json, rss, html, links, compact, tsv, protobuf, or reminders", http.StatusBadRequest)
+		return
+	}
+
+	filters, err := newFilterChain(query, ws.location)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filters.matchPattern = ws.matchPattern
+
+	var minConfidence float64
+	if param := query.Get("minConfidence"); param != "" {
+		var err error
+		minConfidence, err = strconv.ParseFloat(param, 64)
+		if err != nil || minConfidence < 0 || minConfidence > 1 {
+			http.Error(w, fmt.Sprintf("Invalid minConfidence: %s", param), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Both rewrite the datetime, and neither can read the other's output
+	if dateFormat != "" && displayTz != "" {
+		http.Error(w, "dateFormat can't be combined with displayTz", http.StatusBadRequest)
+		return
+	}
+
+	var displayLoc *time.Location
+	if displayTz != "" {
+		var err error
+		displayLoc, err = time.LoadLocation(displayTz)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid displayTz: %s", displayTz), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Resolve the team slug before scraping so typos fail fast
+	if team != "" {
+		var ok bool
+		filters.teamName, ok = ws.normalizer.ResolveTeamSlug(team)
+		if !ok {
+			writeJSONError(w, http.StatusBadRequest, apiError{
+				Error:       fmt.Sprintf("Unknown team: %s", team),
+				Suggestions: ws.normalizer.SuggestTeamSlugs(team, 3),
+			})
+			return
+		}
+	}
 
 	// Set response headers
-	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 
 	// Scrape tickets based on source
-	var result *scraper.ScrapingResult
-	var err error
+	result, err := ws.scrapeCached(r.Context(), source)
+	if errors.Is(err, errInvalidSource) {
+		http.Error(w, invalidSourceMessage(), http.StatusBadRequest)
+		return
+	}
+	var requiredErr *requiredSourceError
+	if errors.As(err, &requiredErr) {
+		writeJSONError(w, http.StatusInternalServerError, apiError{
+			Error:        fmt.Sprintf("Scraping failed: %v", err),
+			SourceStatus: requiredErr.Status,
+			Errors:       requiredErr.Errors,
+		})
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Scraping failed: %v", err), http.StatusInternalServerError)
+		return
+	}
 
-	switch source {
-	case "hellotickets":
-		result, err = scraper.NewScraper().ScrapeRealMadridTickets()
-	case "vividseats":
-		result, err = scraper.NewVividSeatsScraper().ScrapeVividSeatsRealMadridTickets()
-	case "sport365":
-		result, err = scraper.NewSport365Scraper().ScrapeSport365RealMadridMatches()
-	case "all":
-		// Scrape from all sources
-		helloResult, err1 := scraper.NewScraper().ScrapeRealMadridTickets()
-		vividResult, err2 := scraper.NewVividSeatsScraper().ScrapeVividSeatsRealMadridTickets()
-		sportResult, err3 := scraper.NewSport365Scraper().ScrapeSport365RealMadridMatches()
+	// An empty raw scrape usually means the site changed, unlike a filter matching nothing
+	if failEmpty && len(result.Events) == 0 {
+		http.Error(w, fmt.Sprintf("Scrape of %s returned no events; the source page may have changed", source), http.StatusFailedDependency)
+		return
+	}
 
-		if err1 != nil && err2 != nil && err3 != nil {
-			http.Error(w, fmt.Sprintf("Failed to scrape from all sources: %v, %v, %v", err1, err2, err3), http.StatusInternalServerError)
-			return
-		}
+	// Apply normalization if requested
+	if normalize {
+		result = ws.normalizeCached(r.Context(), source, result, minConfidence)
+	}
+
+	// Strip tracking params from links if requested
+	if cleanLinks {
+		result = result.CleanLinks()
+	}
 
-		// Combine results
-		result = &scraper.ScrapingResult{
-			Events:    []scraper.TicketEvent{},
-			Timestamp: time.Now(),
-			SourceURL: "multiple_sources",
-			Source:    "all",
+	// One row per match and source, carrying the cheapest listing
+	if collapseListings {
+		result = result.CollapseListings()
+	}
+
+	// Collapse the same match listed more than once
+	if dedupFuzzy {
+		result = result.DeduplicateFuzzy(ws.config.DedupFuzzyThreshold, ws.config.DedupDayTolerance)
+	} else if dedup {
+		result = result.Deduplicate()
+	}
+
+	// Apply filters
+	result = filters.apply(result, ws.normalizer)
+
+	// Translate canonical team names for display
+	if lang != "" {
+		result = result.LocalizeNames(lang)
+	}
+
+	if includeRound {
+		result = result.IncludeRoundInName()
+	}
+
+	// Convert event times last, since the converted strings no longer match the source formats
+	if displayLoc != nil {
+		result = result.ConvertTimezone(ws.sourceLoc, displayLoc)
+	}
+	if dateFormat != "" {
+		layout := dateFormat
+		if layout == "canonical" {
+			layout = scraper.DefaultDateLayout
 		}
+		result = result.CanonicalizeDates(layout)
+	}
 
-		if helloResult != nil {
-			result.Events = append(result.Events, helloResult.Events...)
+	// Only the human-oriented formats use display names; JSON keeps the source IDs
+	if displayNames {
+		result = result.WithDisplayNames(ws.config.DisplayNames())
+	}
+
+	// Sources cut off by the time budget make the response partial
+	status := http.StatusOK
+	if result.Partial {
+		status = http.StatusPartialContent
+	}
+
+	// Return response in the requested format
+	switch format {
+	case "rss":
+		feed, err := result.FormatAsRSS()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Formatting failed: %v", err), http.StatusInternalServerError)
+			return
 		}
-		if vividResult != nil {
-			result.Events = append(result.Events, vividResult.Events...)
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprint(w, feed)
+	case "compact":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprint(w, result.FormatAsCompactTable())
+	case "links":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprint(w, result.FormatAsLinks())
+	case "reminders":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprint(w, result.FormatAsReminders())
+	case "tsv":
+		table, err := result.FormatAsTSV()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Formatting failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/tab-separated-values; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprint(w, table)
+	case "html":
+		page, err := result.FormatAsHTML()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Formatting failed: %v", err), http.StatusInternalServerError)
+			return
 		}
-		if sportResult != nil {
-			result.Events = append(result.Events, sportResult.Events...)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprint(w, page)
+	case "protobuf":
+		message, err := result.FormatAsProtobuf()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Formatting failed: %v", err), http.StatusInternalServerError)
+			return
 		}
-		result.Total = len(result.Events)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(status)
+		w.Write(message)
 	default:
-		http.Error(w, "Invalid source. Use: hellotickets, vividseats, sport365, or all", http.StatusBadRequest)
+		var body string
+		var err error
+		if priceAsString {
+			body, err = result.FormatAsJSONWithStringPrices(pretty)
+		} else {
+			body, err = result.FormatAsJSON(pretty)
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Formatting failed: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintln(w, body)
+	}
+}
+
+// customScrapeRequest is the body of POST /scrape/custom
+type customScrapeRequest struct {
+	URL      string           `json:"url"`
+	Listing  string           `json:"listing_selector"`
+	FieldMap scraper.FieldMap `json:"field_map"`
+}
+
+// handleCustomScrape scrapes an arbitrary page using the selectors and field map in the request body
+func (ws *WebServer) handleCustomScrape(w http.ResponseWriter, r *http.Request) {
+	var req customScrapeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request body: %v", err), http.StatusBadRequest)
 		return
 	}
 
+	opts := ws.config.ScraperOptions()
+	opts.Limiter = ws.limiter
+	s, err := scraper.NewGenericScraper(req.Listing, req.FieldMap, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.ScrapeContext(r.Context(), req.URL)
+	var scrapeErr *scraper.ScrapeError
+	if err != nil && (!errors.As(err, &scrapeErr) || errors.Is(err, scraper.ErrNonPublicAddress)) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusBadGateway, apiError{
+			Error:  fmt.Sprintf("Scraping failed: %v", err),
+			Errors: []scraper.ScrapeError{*scrapeErr},
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleEvent scrapes a single match page of a source into one detailed event
+func (ws *WebServer) handleEvent(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	pageURL := query.Get("url")
+	source := query.Get("source")
+	if pageURL == "" || source == "" {
+		http.Error(w, "Both url and source are required", http.StatusBadRequest)
+		return
+	}
+	if err := scraper.ValidateSourceURL(source, pageURL); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := ws.scraperOptionsFor(source)
+	s, err := scraper.NewDetailScraper(source, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	event, err := s.ScrapeMatchDetailContext(r.Context(), pageURL)
+	var scrapeErr *scraper.ScrapeError
+	if errors.As(err, &scrapeErr) {
+		writeJSONError(w, http.StatusBadGateway, apiError{
+			Error:  fmt.Sprintf("Scraping failed: %v", err),
+			Errors: []scraper.ScrapeError{*scrapeErr},
+		})
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(event)
+}
+
+// handleNew returns only the listings not seen by any earlier call
+func (ws *WebServer) handleNew(w http.ResponseWriter, r *http.Request) {
+	source := r.URL.Query().Get("source")
+	if source == "" {
+		source = ws.config.DefaultSource
+	}
+
+	result, err := ws.scrapeCached(r.Context(), source)
+	if errors.Is(err, errInvalidSource) {
+		http.Error(w, invalidSourceMessage(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Scraping failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	// Apply normalization if requested
-	if normalize {
-		normalizer := scraper.NewTeamNameNormalizer()
-		result = normalizer.NormalizeScrapingResult(result)
+	fresh, err := ws.seen.markSeen(result.Events, time.Now())
+	if err != nil {
+		// The new listings are still valid; they just may be reported again after a restart
+		log.Printf("Failed to persist seen listings: %v", err)
 	}
 
-	// Apply filters
-	if filter != "" {
-		result = result.FilterByKeyword(filter)
+	newResult := *result
+	newResult.Events = fresh
+	newResult.Total = len(fresh)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&newResult)
+}
+
+// handleStats reports statistics for a source's scrape, including how soon it is worth re-scraping
+func (ws *WebServer) handleStats(w http.ResponseWriter, r *http.Request) {
+	source := r.URL.Query().Get("source")
+	if source == "" {
+		source = ws.config.DefaultSource
+	}
+
+	result, err := ws.scrapeCached(r.Context(), source)
+	if errors.Is(err, errInvalidSource) {
+		http.Error(w, invalidSourceMessage(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Scraping failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result.GetStatistics())
+}
+
+// handlePriceStats reports price statistics over a source's match listings, optionally for one team
+func (ws *WebServer) handlePriceStats(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	source := query.Get("source")
+	if source == "" {
+		source = ws.config.DefaultSource
+	}
+
+	var teamName string
+	if team := query.Get("team"); team != "" {
+		var ok bool
+		teamName, ok = ws.normalizer.ResolveTeamSlug(team)
+		if !ok {
+			writeJSONError(w, http.StatusBadRequest, apiError{
+				Error:       fmt.Sprintf("Unknown team: %s", team),
+				Suggestions: ws.normalizer.SuggestTeamSlugs(team, 3),
+			})
+			return
+		}
+	}
+
+	result, err := ws.scrapeCached(r.Context(), source)
+	if errors.Is(err, errInvalidSource) {
+		http.Error(w, invalidSourceMessage(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Scraping failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Parking and packages would skew the match prices
+	result = result.OnlyMatches()
+	if teamName != "" {
+		result = ws.normalizer.FilterByTeam(result, teamName)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result.PriceStatistics())
+}
+
+// scrapeCached returns a cached result for the source if it is still fresh,
+// otherwise it scrapes the source and caches the result. Scraping stops early when ctx is done.
+func (ws *WebServer) scrapeCached(ctx context.Context, source string) (*scraper.ScrapingResult, error) {
+	// Results scraped with a caller's credentials are theirs alone
+	if _, ok := credentialsFrom(ctx); ok {
+		return ws.scrapeSource(ctx, source)
+	}
+
+	ttl := ws.config.CacheTTL.Duration
+	if ttl > 0 {
+		ws.cacheMu.Lock()
+		entry, ok := ws.cache[source]
+		ws.cacheMu.Unlock()
+		if ok && time.Now().Before(entry.expires) {
+			return entry.result, nil
+		}
+	}
+
+	result, err := ws.scrapeSource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	ws.storeCached(source, result)
+	return result, nil
+}
+
+// storeCached caches the result for the source for cache_ttl, replacing any
+// earlier entry. Nothing is cached when cache_ttl is 0.
+func (ws *WebServer) storeCached(source string, result *scraper.ScrapingResult) {
+	ttl := ws.config.CacheTTL.Duration
+	// Partial results are served once but not cached, so the next request tries the missing sources
+	// again; throttled ones are already held until all_min_interval passes
+	if ttl <= 0 || result.Partial || result.Throttled {
+		return
+	}
+
+	ws.cacheMu.Lock()
+	ws.cache[source] = cacheEntry{result: result, expires: time.Now().Add(ttl)}
+	ws.cacheMu.Unlock()
+}
+
+// normalizeCached returns the normalized copy of a raw result for the source,
+// normalizing it only the first time. The copy is tied to the raw result it came
+// from, so it is dropped as soon as the raw cache refreshes. Team names matched
+// with less than minConfidence are kept as listed; the cut is made per request,
+// so there is one entry per source whatever confidences callers ask for.
+func (ws *WebServer) normalizeCached(ctx context.Context, source string, raw *scraper.ScrapingResult, minConfidence float64) *scraper.ScrapingResult {
+	// Without a raw cache every scrape is fresh, so there is nothing to reuse;
+	// neither is there for a scrape with the caller's credentials
+	_, private := credentialsFrom(ctx)
+	if ws.config.CacheTTL.Duration <= 0 || private {
+		return ws.normalizer.NormalizeScrapingResultWithConfidence(raw, minConfidence)
+	}
+
+	ws.cacheMu.Lock()
+	entry, ok := ws.normalized[source]
+	ws.cacheMu.Unlock()
+	if !ok || entry.raw != raw {
+		entry = normalizedEntry{raw: raw, result: ws.normalizer.NormalizeForConfidence(raw)}
+		ws.cacheMu.Lock()
+		ws.normalized[source] = entry
+		ws.cacheMu.Unlock()
+	}
+	return ws.normalizer.WithMinConfidence(raw, entry.result, minConfidence)
+}
+
+// scrapeSource scrapes the given source (or all sources) using the configured options
+func (ws *WebServer) scrapeSource(ctx context.Context, source string) (*scraper.ScrapingResult, error) {
+	switch source {
+	case "all":
+		return ws.scrapeAllThrottled(ctx)
+	case "fallback":
+		return ws.scrapeFallback(ctx)
+	case "mock":
+		// Fixed data for frontend development, never exposed unless enabled
+		if !ws.config.EnableMock {
+			return nil, errInvalidSource
+		}
+		return scrapeMock()
+	}
+	return ws.scrapeOne(ctx, source)
+}
+
+// scrapeAllThrottled runs an "all" scrape unless one ran within all_min_interval,
+// whatever the cache holds, and serves that scrape's result again, flagged as
+// throttled, instead. Requests arriving mid-scrape share its result, giving up
+// when their own context ends; the mutex only guards the bookkeeping.
+func (ws *WebServer) scrapeAllThrottled(ctx context.Context) (*scraper.ScrapingResult, error) {
+	interval := ws.config.AllMinInterval.Duration
+	if interval <= 0 {
+		return ws.scrapeAll(ctx)
+	}
+
+	ws.allMu.Lock()
+	if ws.lastAll != nil && time.Since(ws.lastAllAt) < interval {
+		throttled := *ws.lastAll
+		ws.allMu.Unlock()
+		throttled.Throttled = true
+		return &throttled, nil
+	}
+	if run := ws.allRun; run != nil {
+		ws.allMu.Unlock()
+		select {
+		case <-run.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if run.err != nil {
+			return nil, run.err
+		}
+		throttled := *run.result
+		throttled.Throttled = true
+		return &throttled, nil
+	}
+	run := &allScrape{done: make(chan struct{})}
+	ws.allRun = run
+	ws.allMu.Unlock()
+
+	started := time.Now()
+	run.result, run.err = ws.scrapeAll(ctx)
+
+	ws.allMu.Lock()
+	if run.err == nil {
+		ws.lastAll, ws.lastAllAt = run.result, started
+	}
+	ws.allRun = nil
+	ws.allMu.Unlock()
+	close(run.done)
+	return run.result, run.err
+}
+
+// scrapeOne scrapes a single source and runs its registered post-processors over the result
+func (ws *WebServer) scrapeOne(ctx context.Context, source string) (*scraper.ScrapingResult, error) {
+	result, err := ws.runScraper(ctx, source)
+	if err != nil {
+		return result, err
+	}
+	return scraper.PostProcess(source, result), nil
+}
+
+// runScraper scrapes a single source, reading its fixture instead when offline mode is enabled
+func (ws *WebServer) runScraper(ctx context.Context, source string) (*scraper.ScrapingResult, error) {
+	s, ok := ws.scraperFor(source)
+	if !ok {
+		return nil, errInvalidSource
+	}
+	opts := ws.scraperOptionsFor(source)
+
+	// A scrape with the caller's credentials gets scrapers of its own, keeping
+	// its cookies out of the shared cookie jar
+	creds, private := credentialsFrom(ctx)
+	opts.Credentials = creds
+	if private {
+		s, _ = scraper.NewSourceScraper(source, opts)
+	}
+
+	// Each source gets its own deadline, also within an "all" scrape. Browser
+	// sources apply theirs once they have a browser, so queueing doesn't count.
+	if info, _ := scraper.DefaultRegistry.Info(source); !info.UsesBrowser {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	if ws.config.OfflineDir != "" {
+		return s.ScrapeFromFile(filepath.Join(ws.config.OfflineDir, source+".html"))
+	}
+	return s.Scrape(ctx)
+}
+
+// scraperFor returns the source's long-lived scraper, building it from the
+// registry the first time, so sources registered after the server started
+// are scraped too. It reports false for an unregistered source.
+func (ws *WebServer) scraperFor(source string) (scraper.SourceScraper, bool) {
+	ws.scrapersMu.Lock()
+	defer ws.scrapersMu.Unlock()
+	if s, ok := ws.scrapers[source]; ok && scraper.IsSource(source) {
+		return s, true
+	}
+	s, ok := scraper.NewSourceScraper(source, ws.scraperOptionsFor(source))
+	if !ok {
+		return nil, false
 	}
+	ws.scrapers[source] = s
+	return s, true
+}
 
-	if dateFrom != "" || dateTo != "" {
-		var startDate, endDate time.Time
-		var parseErr error
+// scraperOptionsFor returns the source's configured scraper options, drawing from the global request budget
+func (ws *WebServer) scraperOptionsFor(source string) scraper.ScraperOptions {
+	opts := ws.config.ScraperOptionsFor(source)
+	opts.Limiter = ws.limiter
+	return opts
+}
+
+// scrapeAll scrapes every source within the shared "all" time budget. Sources
+// still running when the budget runs out are cancelled and reported as "timeout".
+func (ws *WebServer) scrapeAll(ctx context.Context) (*scraper.ScrapingResult, error) {
+	if budget := ws.config.AllBudget.Duration; budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+
+	result := &scraper.ScrapingResult{
+		Events:       []scraper.TicketEvent{},
+		Timestamp:    time.Now(),
+		SourceURL:    "multiple_sources",
+		Source:       "all",
+		SourceStatus: make(map[string]string),
+	}
 
-		if dateFrom != "" {
-			startDate, parseErr = time.Parse("2006-01-02", dateFrom)
-			if parseErr != nil {
-				http.Error(w, fmt.Sprintf("Invalid from date: %s", dateFrom), http.StatusBadRequest)
-				return
+	// Scrape all sources at once, collecting each as it finishes
+	sources := scraper.SourceNames()
+	type outcome struct {
+		source string
+		result *scraper.ScrapingResult
+		err    error
+	}
+	done := make(chan outcome, len(sources))
+	for _, source := range sources {
+		go func(source string) {
+			sourceResult, err := ws.scrapeOne(ctx, source)
+			done <- outcome{source, sourceResult, err}
+		}(source)
+	}
+
+	// When the budget runs out, return the sources that finished instead of waiting for the rest
+	outcomes := make(map[string]outcome)
+collect:
+	for len(outcomes) < len(sources) {
+		select {
+		case o := <-done:
+			outcomes[o.source] = o
+		case <-ctx.Done():
+			for _, source := range sources {
+				if _, finished := outcomes[source]; !finished {
+					outcomes[source] = outcome{source: source, err: ctx.Err()}
+					result.Partial = true
+				}
 			}
-		} else {
-			startDate = time.Now().AddDate(-1, 0, 0) // 1 year ago
+			break collect
+		}
+	}
+
+	// Merge in a fixed order so events don't shuffle with finishing order
+	errs := make(map[string]error)
+	var failures []string
+	for _, source := range sources {
+		o := outcomes[source]
+		ws.mergeSource(result, source, o.result, o.err)
+		errs[source] = o.err
+		if o.err != nil {
+			failures = append(failures, o.err.Error())
 		}
+	}
+
+	if len(failures) == len(sources) {
+		return nil, fmt.Errorf("failed to scrape from all sources: %s", strings.Join(failures, ", "))
+	}
 
-		if dateTo != "" {
-			endDate, parseErr = time.Parse("2006-01-02", dateTo)
-			if parseErr != nil {
-				http.Error(w, fmt.Sprintf("Invalid to date: %s", dateTo), http.StatusBadRequest)
-				return
+	// Optional sources are best-effort, but any required source failing fails the scrape
+	for _, required := range ws.config.RequiredSources {
+		if errs[required] != nil {
+			return nil, &requiredSourceError{Source: required, Err: errs[required], Status: result.SourceStatus, Errors: result.Errors}
+		}
+	}
+
+	result.Total = len(result.Events)
+	return result, nil
+}
+
+// scrapeFallback tries the configured fallback sources in order and returns the
+// first result with events. Failures of the sources tried before it are
+// reported in Errors. If every source that worked came back empty, the first
+// empty result is returned.
+func (ws *WebServer) scrapeFallback(ctx context.Context) (*scraper.ScrapingResult, error) {
+	var empty *scraper.ScrapingResult
+	var failures []scraper.ScrapeError
+	var errs []error
+
+	for _, source := range ws.config.FallbackSources {
+		result, err := ws.scrapeOne(ctx, source)
+		if err != nil {
+			var scrapeErr *scraper.ScrapeError
+			if errors.As(err, &scrapeErr) {
+				failures = append(failures, *scrapeErr)
 			}
-		} else {
-			endDate = time.Now().AddDate(2, 0, 0) // 2 years from now
+			errs = append(errs, err)
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+
+		result.ServedBy = source
+		if len(result.Events) > 0 {
+			result.Errors = append(result.Errors, failures...)
+			return result, nil
 		}
+		if empty == nil {
+			empty = result
+		}
+	}
 
-		result = result.FilterByDate(startDate, endDate)
+	if empty == nil {
+		return nil, fmt.Errorf("every fallback source failed: %w", errors.Join(errs...))
 	}
+	empty.Errors = append(empty.Errors, failures...)
+	return empty, nil
+}
 
-	// Return JSON response
-	json.NewEncoder(w).Encode(result)
+// requiredSourceError reports that a source marked as required failed during an "all" scrape
+type requiredSourceError struct {
+	Source string
+	Err    error
+	Status map[string]string // Status of every source, not just the failed one
+	Errors []scraper.ScrapeError
+}
+
+func (e *requiredSourceError) Error() string {
+	return fmt.Sprintf("required source %s failed: %v", e.Source, e.Err)
+}
+
+func (e *requiredSourceError) Unwrap() error {
+	return e.Err
+}
+
+// mergeSource appends one source's events to the combined result and records
+// its status, judged by the source's own error: a source that failed before
+// the budget ran out is an "error", not a "timeout"
+func (ws *WebServer) mergeSource(result *scraper.ScrapingResult, source string, sourceResult *scraper.ScrapingResult, err error) {
+	switch {
+	case err == nil:
+		result.SourceStatus[source] = "ok"
+	case errors.Is(err, context.DeadlineExceeded):
+		result.SourceStatus[source] = "timeout"
+	default:
+		result.SourceStatus[source] = "error"
+	}
+
+	var scrapeErr *scraper.ScrapeError
+	if errors.As(err, &scrapeErr) {
+		result.Errors = append(result.Errors, *scrapeErr)
+	}
+
+	if sourceResult != nil {
+		result.Events = append(result.Events, sourceResult.Events...)
+		result.AddSourceURL(sourceResult)
+		result.AddSkips(sourceResult)
+	}
+}
+
+// apiError is the structured error body returned for recoverable client errors
+type apiError struct {
+	Error        string                `json:"error"`
+	Suggestions  []string              `json:"suggestions,omitempty"`
+	SourceStatus map[string]string     `json:"source_status,omitempty"`
+	Errors       []scraper.ScrapeError `json:"errors,omitempty"`
+}
+
+// writeJSONError writes an apiError with the given status code
+func writeJSONError(w http.ResponseWriter, status int, body apiError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
 }
 
 // handleHealth handles the health check endpoint
@@ -173,17 +1035,64 @@ func (ws *WebServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 	health := map[string]interface{}{
 		"status":    "healthy",
 		"timestamp": time.Now(),
-		"version":   "1.0.0",
-		"services": map[string]string{
-			"hellotickets": "available",
-			"vividseats":   "available",
-			"sport365":     "available",
-		},
+		"version":   version,
+	}
+	services := make(map[string]string)
+	for _, source := range scraper.SourceNames() {
+		services[source] = "available"
+	}
+	health["services"] = services
+
+	// The deep probe scrapes every source to catch pages that load but no longer parse
+	if r.URL.Query().Get("deep") == "true" {
+		services, healthy := ws.probeSources(r.Context())
+		health["services"] = services
+		if !healthy {
+			health["status"] = "unhealthy"
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
 	}
 
 	json.NewEncoder(w).Encode(health)
 }
 
+// sourceHealth is the outcome of scraping one source during a deep health check
+type sourceHealth struct {
+	Status string `json:"status"` // "healthy" or "unhealthy"
+	Events int    `json:"events"`
+	Error  string `json:"error,omitempty"`
+}
+
+// probeSources scrapes each source, bypassing the cache, and reports it unhealthy
+// if it fails or returns fewer than the configured minimum number of events
+func (ws *WebServer) probeSources(ctx context.Context) (map[string]sourceHealth, bool) {
+	services := make(map[string]sourceHealth)
+	allHealthy := true
+
+	for _, source := range scraper.SourceNames() {
+		health := sourceHealth{Status: "healthy"}
+
+		result, err := ws.scrapeOne(ctx, source)
+		switch {
+		case err != nil:
+			health.Error = err.Error()
+		case len(result.Events) < ws.config.MinHealthyEvents:
+			health.Events = len(result.Events)
+			health.Error = fmt.Sprintf("returned %d events, expected at least %d", len(result.Events), ws.config.MinHealthyEvents)
+		default:
+			health.Events = len(result.Events)
+		}
+
+		if health.Error != "" {
+			health.Status = "unhealthy"
+			allHealthy = false
+		}
+		services[source] = health
+	}
+
+	return services, allHealthy
+}
+
 // handleOptions handles OPTIONS requests for CORS
 func (ws *WebServer) handleOptions(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -214,27 +1123,35 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// loggingMiddleware logs HTTP requests
-func loggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-		next.ServeHTTP(w, r)
-		log.Printf("%s %s %s %v", r.Method, r.RequestURI, r.RemoteAddr, time.Since(start))
-	})
-}
-
 func main() {
-	port := flag.String("port", "8080", "Port to run the web server on")
+	flags := registerConfigFlags(flag.CommandLine)
 	flag.Parse()
 
+	config, err := flags.resolve(flag.CommandLine)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	setupLogging(config.LogFormat)
+
 	// API-only mode - no web directory required
 
 	fmt.Println("🚀 Real Madrid Ticket Scraper API")
 	fmt.Println("=================================")
-	fmt.Printf("Starting API server on port %s...\n", *port)
+	fmt.Printf("Starting API server on port %s...\n", config.Port)
 	fmt.Println()
 
 	// Create and start web server
-	server := NewWebServer(*port)
-	log.Fatal(server.Start())
+	server, err := NewWebServer(config)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.Start(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// handleSources lists the sources and the event fields each of them fills in
+func (ws *WebServer) handleSources(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scraper.SourceInfos())
 }
@@ -0,0 +1,602 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"normalizer/scraper"
+)
+
+// newTestServer starts the API on an httptest server with the default config,
+// after letting configure adjust it
+func newTestServer(t *testing.T, configure func(*Config)) (*WebServer, *httptest.Server) {
+	t.Helper()
+	config := DefaultConfig()
+	config.RateLimit.Duration = 0
+	if configure != nil {
+		configure(&config)
+	}
+
+	ws, err := NewWebServer(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(ws.router())
+	t.Cleanup(srv.Close)
+	return ws, srv
+}
+
+// get fetches path from srv, returning the response and its body
+func get(t *testing.T, srv *httptest.Server, path string) (*http.Response, string) {
+	t.Helper()
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func TestScrapeLinksFormat(t *testing.T) {
+	_, srv := newTestServer(t, func(c *Config) { c.EnableMock = true })
+
+	resp, body := get(t, srv, "/scrape?source=mock&format=links&filter=barcelona")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", ct)
+	}
+
+	links := strings.Split(strings.TrimSpace(body), "\n")
+	seen := make(map[string]bool)
+	for _, link := range links {
+		if !strings.HasPrefix(link, "https://") {
+			t.Errorf("line %q is not a link", link)
+		}
+		if seen[link] {
+			t.Errorf("link %q repeated", link)
+		}
+		seen[link] = true
+	}
+	if len(links) == 0 || !strings.Contains(strings.ToLower(body), "barcelona") {
+		t.Errorf("expected the filtered Barcelona links, got %q", body)
+	}
+}
+
+// post sends body to path on srv as JSON, returning the response and its body
+func post(t *testing.T, srv *httptest.Server, path, body string) (*http.Response, string) {
+	t.Helper()
+	resp, err := http.Post(srv.URL+path, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(data)
+}
+
+func TestCustomScrapeRejectsNonPublicURL(t *testing.T) {
+	_, srv := newTestServer(t, nil)
+
+	for _, target := range []string{"http://169.254.169.254/latest/meta-data/", srv.URL + "/health"} {
+		resp, body := post(t, srv, "/scrape/custom", `{"url": "`+target+`", "listing_selector": "li", "field_map": {"event": ".name"}}`)
+		if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, "non-public address") {
+			t.Errorf("custom scrape of %s: status %d, body %q; want 400 for a non-public address", target, resp.StatusCode, body)
+		}
+	}
+}
+
+func TestScrapeEmptySourceIsValid(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "vividseats.html"), []byte("<html><body></body></html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, srv := newTestServer(t, func(c *Config) { c.OfflineDir = dir })
+
+	for _, query := range []string{"", "&normalize=true&filter=madrid&from=2025-01-01&to=2025-12-31", "&format=links", "&format=tsv"} {
+		resp, body := get(t, srv, "/scrape?source=vividseats"+query)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("%s: status %d: %s", query, resp.StatusCode, body)
+			continue
+		}
+		if query == "" || strings.Contains(query, "normalize") {
+			var result scraper.ScrapingResult
+			if err := json.Unmarshal([]byte(body), &result); err != nil {
+				t.Fatalf("%s: %v", query, err)
+			}
+			if result.Total != 0 || result.Events == nil || len(result.Events) != 0 {
+				t.Errorf("%s: got total %d, events %#v; want 0 and []", query, result.Total, result.Events)
+			}
+		}
+	}
+}
+
+// stubScraper is a SourceScraper returning a fixed result after a delay, or
+// its context's error when the context ends first
+type stubScraper struct {
+	name   string
+	delay  time.Duration
+	events []scraper.TicketEvent
+	err    error
+
+	calls    atomic.Int32
+	deadline atomic.Int64 // Time left before the context's deadline when Scrape was last called, in nanoseconds
+}
+
+func (s *stubScraper) Name() string { return s.name }
+
+func (s *stubScraper) Scrape(ctx context.Context) (*scraper.ScrapingResult, error) {
+	s.calls.Add(1)
+	if deadline, ok := ctx.Deadline(); ok {
+		s.deadline.Store(int64(time.Until(deadline)))
+	}
+
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	events := append([]scraper.TicketEvent{}, s.events...)
+	return &scraper.ScrapingResult{Events: events, Total: len(events), Source: s.name, SourceURL: "https://" + s.name + ".example", Timestamp: time.Now()}, nil
+}
+
+func (s *stubScraper) ScrapeFromFile(path string) (*scraper.ScrapingResult, error) {
+	return s.Scrape(context.Background())
+}
+
+// useScrapers makes ws scrape its sources with the given stubs
+func useScrapers(ws *WebServer, stubs ...*stubScraper) {
+	ws.scrapersMu.Lock()
+	defer ws.scrapersMu.Unlock()
+	for _, stub := range stubs {
+		ws.scrapers[stub.name] = stub
+	}
+}
+
+func TestSourceTimeoutsAreHonored(t *testing.T) {
+	ws, _ := newTestServer(t, func(c *Config) {
+		c.SourceTimeouts = map[string]Duration{
+			"hellotickets": {50 * time.Millisecond},
+			"vividseats":   {300 * time.Millisecond},
+		}
+	})
+	hello := &stubScraper{name: "hellotickets", delay: time.Hour}
+	vivid := &stubScraper{name: "vividseats", delay: time.Hour}
+	useScrapers(ws, hello, vivid)
+
+	for _, tc := range []struct {
+		stub    *stubScraper
+		timeout time.Duration
+	}{
+		{hello, 50 * time.Millisecond},
+		{vivid, 300 * time.Millisecond},
+	} {
+		start := time.Now()
+		_, err := ws.scrapeOne(context.Background(), tc.stub.name)
+		elapsed := time.Since(start)
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: err = %v, want a timeout", tc.stub.name, err)
+		}
+		if left := time.Duration(tc.stub.deadline.Load()); left > tc.timeout || left < tc.timeout-50*time.Millisecond {
+			t.Errorf("%s: scrape started with %v left, want about %v", tc.stub.name, left, tc.timeout)
+		}
+		if elapsed < tc.timeout || elapsed > tc.timeout+time.Second {
+			t.Errorf("%s: gave up after %v, want about %v", tc.stub.name, elapsed, tc.timeout)
+		}
+	}
+}
+
+func TestAllAppliesEachSourceTimeout(t *testing.T) {
+	ws, _ := newTestServer(t, func(c *Config) {
+		c.SourceTimeouts = map[string]Duration{
+			"hellotickets": {50 * time.Millisecond},
+			"vividseats":   {2 * time.Second},
+			"sport365":     {2 * time.Second},
+		}
+	})
+	event := scraper.TicketEvent{Event: "Real Madrid vs Getafe", Link: "https://vividseats.example/1", Source: "vividseats"}
+	useScrapers(ws,
+		&stubScraper{name: "hellotickets", delay: time.Hour},
+		// Outlasts hellotickets' timeout, but not its own
+		&stubScraper{name: "vividseats", delay: 200 * time.Millisecond, events: []scraper.TicketEvent{event}},
+		&stubScraper{name: "sport365"},
+	)
+
+	result, err := ws.scrapeAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"hellotickets": "timeout", "vividseats": "ok", "sport365": "ok"}
+	for source, status := range want {
+		if result.SourceStatus[source] != status {
+			t.Errorf("%s status = %q, want %q", source, result.SourceStatus[source], status)
+		}
+	}
+	if result.Total != 1 {
+		t.Errorf("got %d events, want vividseats' one", result.Total)
+	}
+}
+
+func TestAllBudgetKeepsEarlyErrors(t *testing.T) {
+	ws, _ := newTestServer(t, func(c *Config) {
+		c.AllBudget = Duration{100 * time.Millisecond}
+		c.SourceTimeouts = map[string]Duration{"hellotickets": {time.Hour}, "vividseats": {time.Hour}, "sport365": {time.Hour}}
+	})
+	event := scraper.TicketEvent{Event: "Real Madrid vs Getafe", Link: "https://sport365.example/1", Source: "sport365"}
+	useScrapers(ws,
+		// Fails straight away, long before the budget runs out
+		&stubScraper{name: "hellotickets", err: errors.New("unexpected markup")},
+		// Outlives the budget
+		&stubScraper{name: "vividseats", delay: time.Hour},
+		&stubScraper{name: "sport365", events: []scraper.TicketEvent{event}},
+	)
+
+	result, err := ws.scrapeAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"hellotickets": "error", "vividseats": "timeout", "sport365": "ok"}
+	for source, status := range want {
+		if result.SourceStatus[source] != status {
+			t.Errorf("%s status = %q, want %q", source, result.SourceStatus[source], status)
+		}
+	}
+	if !result.Partial {
+		t.Error("result not marked partial after the budget ran out")
+	}
+}
+
+func TestNormalizedCacheKeyedBySource(t *testing.T) {
+	ws, _ := newTestServer(t, func(c *Config) {
+		c.CacheTTL = Duration{time.Hour}
+	})
+	raw := &scraper.ScrapingResult{Events: []scraper.TicketEvent{{Event: "Real Madrid vs Sevila", Source: "hellotickets"}}}
+
+	for _, minConfidence := range []float64{0, 0.5, 0.75, 0.99, 0.5} {
+		got := ws.normalizeCached(context.Background(), "hellotickets", raw, minConfidence)
+		want := ws.normalizer.NormalizeScrapingResultWithConfidence(raw, minConfidence)
+		if got.Events[0].Event != want.Events[0].Event || got.Events[0].NormalizedConfidence != want.Events[0].NormalizedConfidence {
+			t.Errorf("minConfidence %g: got %+v, want %+v", minConfidence, got.Events[0], want.Events[0])
+		}
+	}
+	if len(ws.normalized) != 1 {
+		t.Errorf("normalized cache holds %d entries, want one for the source", len(ws.normalized))
+	}
+}
+
+// scrapeJSON fetches path from srv and decodes the result, failing unless the status is want
+func scrapeJSON(t *testing.T, srv *httptest.Server, path string, want int) scraper.ScrapingResult {
+	t.Helper()
+	resp, body := get(t, srv, path)
+	if resp.StatusCode != want {
+		t.Fatalf("GET %s: status %d, want %d: %s", path, resp.StatusCode, want, body)
+	}
+	var result scraper.ScrapingResult
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	return result
+}
+
+func TestFallbackFirstSourceFails(t *testing.T) {
+	ws, srv := newTestServer(t, func(c *Config) { c.FallbackSources = []string{"vividseats", "hellotickets"} })
+	blocked := &scraper.ScrapeError{Source: "vividseats", URL: "https://www.vividseats.com/x", Kind: scraper.ErrorKindBlocked, Err: errors.New("403 Forbidden")}
+	hello := &stubScraper{name: "hellotickets", events: []scraper.TicketEvent{{Event: "Real Madrid vs Getafe", Link: "https://hellotickets.example/1"}}}
+	useScrapers(ws, &stubScraper{name: "vividseats", err: blocked}, hello)
+
+	result := scrapeJSON(t, srv, "/scrape?source=fallback", http.StatusOK)
+	if result.ServedBy != "hellotickets" || result.Total != 1 {
+		t.Errorf("served by %q with %d events, want hellotickets with 1", result.ServedBy, result.Total)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Source != "vividseats" || result.Errors[0].Kind != scraper.ErrorKindBlocked {
+		t.Errorf("errors = %+v, want vividseats' block", result.Errors)
+	}
+}
+
+func TestFallbackSkipsEmptySource(t *testing.T) {
+	ws, srv := newTestServer(t, func(c *Config) { c.FallbackSources = []string{"vividseats", "hellotickets"} })
+	vivid := &stubScraper{name: "vividseats"}
+	hello := &stubScraper{name: "hellotickets", events: []scraper.TicketEvent{{Event: "Real Madrid vs Getafe", Link: "https://hellotickets.example/1"}}}
+	useScrapers(ws, vivid, hello)
+
+	if result := scrapeJSON(t, srv, "/scrape?source=fallback", http.StatusOK); result.ServedBy != "hellotickets" {
+		t.Errorf("served by %q, want hellotickets after vividseats came back empty", result.ServedBy)
+	}
+
+	// With every source empty, the first one's empty result is served
+	hello.events = nil
+	ws.cache = make(map[string]cacheEntry)
+	if result := scrapeJSON(t, srv, "/scrape?source=fallback", http.StatusOK); result.ServedBy != "vividseats" || result.Total != 0 {
+		t.Errorf("served by %q with %d events, want vividseats' empty result", result.ServedBy, result.Total)
+	}
+}
+
+func TestFallbackEverySourceFails(t *testing.T) {
+	ws, srv := newTestServer(t, func(c *Config) { c.FallbackSources = []string{"vividseats", "hellotickets"} })
+	useScrapers(ws,
+		&stubScraper{name: "vividseats", err: errors.New("connection refused")},
+		&stubScraper{name: "hellotickets", err: errors.New("connection reset")},
+	)
+
+	resp, body := get(t, srv, "/scrape?source=fallback")
+	if resp.StatusCode < 500 || !strings.Contains(body, "every fallback source failed") {
+		t.Errorf("status %d, body %q; want a server error naming the fallback", resp.StatusCode, body)
+	}
+}
+
+func TestScrapeTSVFormat(t *testing.T) {
+	_, srv := newTestServer(t, func(c *Config) { c.EnableMock = true })
+
+	resp, body := get(t, srv, "/scrape?source=mock&format=tsv")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/tab-separated-values") {
+		t.Errorf("Content-Type = %q, want text/tab-separated-values", ct)
+	}
+	lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "datetime\tevent\tlink") {
+		t.Fatalf("want a header and rows, got %q", body)
+	}
+	for _, line := range lines {
+		if n := strings.Count(line, "\t"); n != 8 {
+			t.Errorf("line %q has %d tabs, want 8", line, n)
+		}
+	}
+}
+
+func TestSourcesServedFromRegistry(t *testing.T) {
+	_, srv := newTestServer(t, nil)
+
+	resp, body := get(t, srv, "/sources")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	var sources []scraper.SourceInfo
+	if err := json.Unmarshal([]byte(body), &sources); err != nil {
+		t.Fatal(err)
+	}
+	if want := scraper.SourceInfos(); !slices.Equal(sources, want) {
+		t.Errorf("/sources = %+v, want the registry's %+v", sources, want)
+	}
+}
+
+func TestScrapeRejectsInvalidNamePatterns(t *testing.T) {
+	_, srv := newTestServer(t, func(c *Config) { c.EnableMock = true })
+
+	for _, param := range []string{"allowPatterns", "denyPatterns"} {
+		resp, body := get(t, srv, "/scrape?source=mock&"+param+"=%5Bunclosed")
+		if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, param) {
+			t.Errorf("%s: status %d, body %q; want 400 naming the param", param, resp.StatusCode, body)
+		}
+	}
+}
+
+func TestScrapeAppliesPostProcessors(t *testing.T) {
+	ws, srv := newTestServer(t, nil)
+	useScrapers(ws, &stubScraper{name: "vividseats", events: []scraper.TicketEvent{
+		{Event: "Real Madrid vs Getafe", Link: "https://vividseats.example/1"},
+		{Event: "Gift cards", Link: "https://vividseats.example/postprocess-promo"},
+	}})
+	scraper.RegisterPostProcessor("vividseats", func(r *scraper.ScrapingResult) *scraper.ScrapingResult {
+		var events []scraper.TicketEvent
+		for _, event := range r.Events {
+			if !strings.HasSuffix(event.Link, "postprocess-promo") {
+				events = append(events, event)
+			}
+		}
+		r.Events = events
+		return r
+	})
+
+	result := scrapeJSON(t, srv, "/scrape?source=vividseats", http.StatusOK)
+	if result.Total != 1 || result.Events[0].Event != "Real Madrid vs Getafe" {
+		t.Errorf("got %d events %+v, want the promo listing dropped", result.Total, result.Events)
+	}
+}
+
+// allStubs stubs every source with one event each, delayed by delay
+func allStubs(ws *WebServer, delay time.Duration) []*stubScraper {
+	var stubs []*stubScraper
+	for _, source := range scraper.SourceNames() {
+		stubs = append(stubs, &stubScraper{name: source, delay: delay, events: []scraper.TicketEvent{
+			{Event: "Real Madrid vs Getafe", Link: "https://" + source + ".example/1", Source: source},
+		}})
+	}
+	useScrapers(ws, stubs...)
+	return stubs
+}
+
+func TestAllMinIntervalScrapesOnce(t *testing.T) {
+	ws, srv := newTestServer(t, func(c *Config) { c.AllMinInterval = Duration{time.Hour} })
+	stubs := allStubs(ws, 0)
+
+	first := scrapeJSON(t, srv, "/scrape?source=all&nocache=true", http.StatusOK)
+	second := scrapeJSON(t, srv, "/scrape?source=all&nocache=true", http.StatusOK)
+	for _, stub := range stubs {
+		if calls := stub.calls.Load(); calls != 1 {
+			t.Errorf("%s scraped %d times, want once", stub.name, calls)
+		}
+	}
+	if first.Throttled || !second.Throttled {
+		t.Errorf("throttled = %v then %v, want false then true", first.Throttled, second.Throttled)
+	}
+	if second.Total != first.Total {
+		t.Errorf("throttled result has %d events, want the last scrape's %d", second.Total, first.Total)
+	}
+}
+
+func TestAllMinIntervalWaitersShareScrape(t *testing.T) {
+	ws, _ := newTestServer(t, func(c *Config) { c.AllMinInterval = Duration{time.Hour} })
+	stubs := allStubs(ws, 300*time.Millisecond)
+
+	leader := make(chan error, 1)
+	go func() {
+		_, err := ws.scrapeAllThrottled(context.Background())
+		leader <- err
+	}()
+	for stubs[0].calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A caller that gives up stops waiting rather than sitting out the scrape
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	if _, err := ws.scrapeAllThrottled(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("cancelled waiter got %v, want its deadline", err)
+	}
+	if waited := time.Since(started); waited > 200*time.Millisecond {
+		t.Errorf("cancelled waiter returned after %v, want it to stop at its deadline", waited)
+	}
+
+	// One that waits gets the running scrape's result
+	result, err := ws.scrapeAllThrottled(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Throttled || result.Total != len(stubs) {
+		t.Errorf("waiter got throttled = %v with %d events, want the shared scrape's %d", result.Throttled, result.Total, len(stubs))
+	}
+	if err := <-leader; err != nil {
+		t.Fatal(err)
+	}
+	for _, stub := range stubs {
+		if calls := stub.calls.Load(); calls != 1 {
+			t.Errorf("%s scraped %d times, want once", stub.name, calls)
+		}
+	}
+}
+
+func TestAllCombinesEverySource(t *testing.T) {
+	ws, srv := newTestServer(t, nil)
+	hello := &stubScraper{name: "hellotickets", delay: 100 * time.Millisecond, events: []scraper.TicketEvent{
+		{Event: "Real Madrid vs Getafe", DateTime: "27 Sep 2030", Link: "https://hellotickets.example/1", Source: "hellotickets"},
+		{Event: "Real Madrid vs Sevilla", DateTime: "4 Oct 2030", Link: "https://hellotickets.example/2", Source: "hellotickets"},
+	}}
+	vivid := &stubScraper{name: "vividseats", delay: 100 * time.Millisecond, events: []scraper.TicketEvent{
+		{Event: "Real Madrid vs Alaves", DateTime: "18 Oct 2030", Link: "https://vividseats.example/1", Source: "vividseats"},
+	}}
+	sport := &stubScraper{name: "sport365", delay: 100 * time.Millisecond, events: []scraper.TicketEvent{
+		{Event: "Real Madrid vs Osasuna", DateTime: "1 Nov 2030", Link: "https://sport365.example/1", Source: "sport365"},
+		{Event: "Real Madrid vs Girona", DateTime: "8 Nov 2030", Link: "https://sport365.example/2", Source: "sport365"},
+		{Event: "Real Madrid vs Villarreal", DateTime: "15 Nov 2030", Link: "https://sport365.example/3", Source: "sport365"},
+	}}
+	useScrapers(ws, hello, vivid, sport)
+
+	started := time.Now()
+	result := scrapeJSON(t, srv, "/scrape?source=all", http.StatusOK)
+	if took := time.Since(started); took > 250*time.Millisecond {
+		t.Errorf("all scrape took %v, want the sources scraped side by side", took)
+	}
+	if want := len(hello.events) + len(vivid.events) + len(sport.events); result.Total != want || len(result.Events) != want {
+		t.Errorf("combined total %d (%d events), want the sum of every source's %d", result.Total, len(result.Events), want)
+	}
+
+	// One source failing leaves the others' events
+	vivid.err = errors.New("connection refused")
+	ws.cache = make(map[string]cacheEntry)
+	result = scrapeJSON(t, srv, "/scrape?source=all", http.StatusOK)
+	if want := len(hello.events) + len(sport.events); result.Total != want {
+		t.Errorf("total with vividseats down = %d, want %d", result.Total, want)
+	}
+
+	// Only every source failing fails the request
+	hello.err, sport.err = errors.New("connection reset"), errors.New("no browser")
+	ws.cache = make(map[string]cacheEntry)
+	if resp, body := get(t, srv, "/scrape?source=all"); resp.StatusCode < 500 {
+		t.Errorf("status %d with every source down, want a server error: %s", resp.StatusCode, body)
+	}
+}
+
+func TestScrapeRemindersFormat(t *testing.T) {
+	ws, srv := newTestServer(t, nil)
+	useScrapers(ws, &stubScraper{name: "vividseats", events: []scraper.TicketEvent{
+		{DateTime: "20 Dec 2030", Event: "Real Madrid vs Betis", Link: "https://vividseats.example/1"},
+	}})
+
+	resp, body := get(t, srv, "/scrape?source=vividseats&format=reminders")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/plain; charset=utf-8" {
+		t.Fatalf("status %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if want := "December 2030\n20 Dec 2030 — Real Madrid vs Betis — https://vividseats.example/1\n"; body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+func TestScrapeDisplayNames(t *testing.T) {
+	ws, srv := newTestServer(t, nil)
+	useScrapers(ws, &stubScraper{name: "vividseats", events: []scraper.TicketEvent{
+		{DateTime: "20 Dec 2030", Event: "Real Madrid vs Betis", Link: "https://vividseats.example/1", Source: "vividseats"},
+	}})
+
+	if _, table := get(t, srv, "/scrape?source=vividseats&format=compact&displayNames=true"); !strings.Contains(table, "VividSeats") || strings.Contains(table, "vividseats") {
+		t.Errorf("table = %q, want the display name instead of the ID", table)
+	}
+	result := scrapeJSON(t, srv, "/scrape?source=vividseats&displayNames=true", http.StatusOK)
+	if result.Source != "vividseats" || result.Events[0].Source != "vividseats" {
+		t.Errorf("JSON sources = %q, %q; want the vividseats ID", result.Source, result.Events[0].Source)
+	}
+}
+
+func TestSourceRegisteredAfterStart(t *testing.T) {
+	_, srv := newTestServer(t, nil)
+	fake := &stubScraper{name: "fake", events: []scraper.TicketEvent{
+		{Event: "Real Madrid vs Getafe", Link: "https://fake.example/1", Source: "fake"},
+	}}
+	scraper.DefaultRegistry.Register(
+		scraper.SourceInfo{Name: "fake", DisplayName: "Fake Tickets", Domain: "fake.example"},
+		func(scraper.ScraperOptions) scraper.SourceScraper { return fake },
+	)
+	t.Cleanup(func() { scraper.DefaultRegistry.Unregister("fake") })
+
+	result := scrapeJSON(t, srv, "/scrape?source=fake", http.StatusOK)
+	if result.Total != 1 || result.Source != "fake" {
+		t.Errorf("got %d events from %q, want the fake source's one", result.Total, result.Source)
+	}
+
+	_, body := get(t, srv, "/health")
+	var health struct {
+		Services map[string]string `json:"services"`
+	}
+	if err := json.Unmarshal([]byte(body), &health); err != nil {
+		t.Fatal(err)
+	}
+	if health.Services["fake"] != "available" {
+		t.Errorf("/health services = %v, want fake listed", health.Services)
+	}
+
+	_, body = get(t, srv, "/sources")
+	if !strings.Contains(body, `"domain":"fake.example"`) {
+		t.Errorf("/sources = %s, want the fake source", body)
+	}
+
+	// Once unregistered, the server no longer scrapes it
+	scraper.DefaultRegistry.Unregister("fake")
+	if resp, _ := get(t, srv, "/scrape?source=fake"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status %d for an unregistered source, want 400", resp.StatusCode)
+	}
+}